@@ -5,6 +5,11 @@ package behaviors
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -31,6 +36,13 @@ type BehaviorEdge struct {
 	Weight     int // For prioritization
 	Latency    time.Duration
 	Deterministic bool
+
+	// Probability is the chance, from 0 to 1, that the edge is eligible on
+	// a given StateMachine step. Probabilistic marks it as set explicitly;
+	// otherwise the zero value means the edge is always eligible, so only
+	// explicit probabilities, including 0, are ever rolled.
+	Probability   float64
+	Probabilistic bool
 }
 
 // BehaviorGraph models the complete behavior space as a directed graph
@@ -38,6 +50,8 @@ type BehaviorGraph struct {
 	mu    sync.RWMutex
 	Nodes map[string]*BehaviorNode
 	Edges map[string][]*BehaviorEdge
+
+	terminal map[string]bool // nodes allowed to have no outgoing edges
 }
 
 // NewBehaviorGraph creates an empty behavior graph
@@ -48,6 +62,18 @@ func NewBehaviorGraph() *BehaviorGraph {
 	}
 }
 
+// MarkTerminal records that a node is meant to end a behavior, so having
+// no outgoing edges is not reported as a dead end
+func (bg *BehaviorGraph) MarkTerminal(nodeID string) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	if bg.terminal == nil {
+		bg.terminal = make(map[string]bool)
+	}
+	bg.terminal[nodeID] = true
+}
+
 // AddNode adds a behavior node to the graph
 func (bg *BehaviorGraph) AddNode(node *BehaviorNode) error {
 	bg.mu.Lock()
@@ -85,6 +111,154 @@ func (bg *BehaviorGraph) AddEdge(from, to string, cond func() bool, latency time
 	return nil
 }
 
+// AddProbabilisticEdge adds a transition that is eligible with the given
+// probability on each StateMachine step
+func (bg *BehaviorGraph) AddProbabilisticEdge(from, to string, prob float64, latency time.Duration) error {
+	if prob < 0 || prob > 1 || math.IsNaN(prob) {
+		return fmt.Errorf("probability %v for edge %s->%s is outside [0, 1]", prob, from, to)
+	}
+
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	if _, exists := bg.Nodes[from]; !exists {
+		return fmt.Errorf("source node %s does not exist", from)
+	}
+	if _, exists := bg.Nodes[to]; !exists {
+		return fmt.Errorf("target node %s does not exist", to)
+	}
+
+	edge := &BehaviorEdge{
+		From:          from,
+		To:            to,
+		Weight:        1,
+		Latency:       latency,
+		Probability:   prob,
+		Probabilistic: true,
+	}
+	bg.Edges[from] = append(bg.Edges[from], edge)
+	return nil
+}
+
+// RemoveNode deletes a node along with its outgoing edges and every edge
+// from another node that points at it
+func (bg *BehaviorGraph) RemoveNode(id string) error {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	_, err := bg.removeNodeLocked(id)
+	return err
+}
+
+// removedNode holds everything removeNodeLocked took out of the graph, so
+// restoreNodeLocked can put it back exactly as it was
+type removedNode struct {
+	node     *BehaviorNode
+	outgoing []*BehaviorEdge
+	hadEdges bool // whether the node had an entry in Edges
+	terminal bool
+	inbound  map[string][]indexedEdge
+}
+
+// indexedEdge is an edge and its position in its source node's edge slice
+type indexedEdge struct {
+	index int
+	edge  *BehaviorEdge
+}
+
+// removeNodeLocked removes a node and every edge touching it. The caller
+// must hold the write lock.
+func (bg *BehaviorGraph) removeNodeLocked(id string) (*removedNode, error) {
+	node, exists := bg.Nodes[id]
+	if !exists {
+		return nil, fmt.Errorf("node %s does not exist", id)
+	}
+
+	removed := &removedNode{
+		node:     node,
+		terminal: bg.terminal[id],
+		inbound:  make(map[string][]indexedEdge),
+	}
+	removed.outgoing, removed.hadEdges = bg.Edges[id]
+	delete(bg.Nodes, id)
+	delete(bg.Edges, id)
+	delete(bg.terminal, id)
+
+	for from, edges := range bg.Edges {
+		kept := make([]*BehaviorEdge, 0, len(edges))
+		for i, edge := range edges {
+			if edge.To == id {
+				removed.inbound[from] = append(removed.inbound[from], indexedEdge{index: i, edge: edge})
+				continue
+			}
+			kept = append(kept, edge)
+		}
+		bg.Edges[from] = kept
+	}
+	return removed, nil
+}
+
+// restoreNodeLocked undoes removeNodeLocked, putting inbound edges back at
+// their original positions. The caller must hold the write lock.
+func (bg *BehaviorGraph) restoreNodeLocked(removed *removedNode) {
+	id := removed.node.ID
+	bg.Nodes[id] = removed.node
+	if removed.hadEdges {
+		bg.Edges[id] = removed.outgoing
+	}
+	if removed.terminal {
+		if bg.terminal == nil {
+			bg.terminal = make(map[string]bool)
+		}
+		bg.terminal[id] = true
+	}
+
+	for from, inbound := range removed.inbound {
+		edges := bg.Edges[from]
+		restored := make([]*BehaviorEdge, 0, len(edges)+len(inbound))
+		next := 0
+		for _, ie := range inbound {
+			for len(restored) < ie.index && next < len(edges) {
+				restored = append(restored, edges[next])
+				next++
+			}
+			restored = append(restored, ie.edge)
+		}
+		bg.Edges[from] = append(restored, edges[next:]...)
+	}
+}
+
+// removeEdgeLocked removes one specific edge, leaving any parallel edges
+// between the same nodes. The caller must hold the write lock.
+func (bg *BehaviorGraph) removeEdgeLocked(target *BehaviorEdge) {
+	edges := bg.Edges[target.From]
+	for i, edge := range edges {
+		if edge == target {
+			bg.Edges[target.From] = append(edges[:i:i], edges[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveEdge deletes every edge from one node to another
+func (bg *BehaviorGraph) RemoveEdge(from, to string) error {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	edges := bg.Edges[from]
+	kept := make([]*BehaviorEdge, 0, len(edges))
+	for _, edge := range edges {
+		if edge.To != to {
+			kept = append(kept, edge)
+		}
+	}
+	if len(kept) == len(edges) {
+		return fmt.Errorf("edge %s->%s does not exist", from, to)
+	}
+	bg.Edges[from] = kept
+	return nil
+}
+
 // GetSuccessors returns all valid next behaviors from a given node
 func (bg *BehaviorGraph) GetSuccessors(nodeID string) ([]*BehaviorEdge, error) {
 	bg.mu.RLock()
@@ -104,16 +278,230 @@ func (bg *BehaviorGraph) GetSuccessors(nodeID string) ([]*BehaviorEdge, error) {
 	return valid, nil
 }
 
+// clone returns a deep copy of the graph's nodes and edges. Edge conditions are
+// shared since they are functions.
+func (bg *BehaviorGraph) clone() *BehaviorGraph {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	c := NewBehaviorGraph()
+	c.Nodes = copyNodes(bg.Nodes)
+	c.Edges = copyEdges(bg.Edges)
+	c.terminal = copyTerminal(bg.terminal)
+	return c
+}
+
+// copyTerminal copies the set of nodes marked terminal
+func copyTerminal(terminal map[string]bool) map[string]bool {
+	if terminal == nil {
+		return nil
+	}
+	copied := make(map[string]bool, len(terminal))
+	for id, t := range terminal {
+		copied[id] = t
+	}
+	return copied
+}
+
+// copyNodes deep-copies a node map, including constraints and metadata
+func copyNodes(nodes map[string]*BehaviorNode) map[string]*BehaviorNode {
+	copied := make(map[string]*BehaviorNode, len(nodes))
+	for id, node := range nodes {
+		n := *node
+		n.Constraints = append([]string(nil), node.Constraints...)
+		if node.Metadata != nil {
+			n.Metadata = make(map[string]interface{}, len(node.Metadata))
+			for k, v := range node.Metadata {
+				n.Metadata[k] = v
+			}
+		}
+		copied[id] = &n
+	}
+	return copied
+}
+
+// copyEdges deep-copies an adjacency map so edge fields can change independently
+func copyEdges(edges map[string][]*BehaviorEdge) map[string][]*BehaviorEdge {
+	copied := make(map[string][]*BehaviorEdge, len(edges))
+	for from, list := range edges {
+		c := make([]*BehaviorEdge, 0, len(list))
+		for _, edge := range list {
+			e := *edge
+			c = append(c, &e)
+		}
+		copied[from] = c
+	}
+	return copied
+}
+
+// GraphSnapshot is a deep copy of a graph's nodes and edges taken by Snapshot
+type GraphSnapshot struct {
+	nodes     map[string]*BehaviorNode
+	edges     map[string][]*BehaviorEdge
+	terminal  map[string]bool
+	Timestamp time.Time
+}
+
+// Snapshot captures a deep copy of the graph's nodes and edges
+func (bg *BehaviorGraph) Snapshot() *GraphSnapshot {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	return &GraphSnapshot{
+		nodes:     copyNodes(bg.Nodes),
+		edges:     copyEdges(bg.Edges),
+		terminal:  copyTerminal(bg.terminal),
+		Timestamp: time.Now(),
+	}
+}
+
+// Restore replaces the graph's nodes and edges with a copy of the snapshot,
+// undoing any changes made since it was taken. The snapshot stays reusable.
+func (bg *BehaviorGraph) Restore(s *GraphSnapshot) {
+	if s == nil {
+		return
+	}
+
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	bg.Nodes = copyNodes(s.nodes)
+	bg.Edges = copyEdges(s.edges)
+	bg.terminal = copyTerminal(s.terminal)
+}
+
+// Validate reports structural problems: edges pointing at missing nodes,
+// nodes unreachable from any entry point, and dead-end nodes with no
+// outgoing edges. Entry points are nodes with no incoming edges, or the
+// lexicographically first node when every node has one.
+func (bg *BehaviorGraph) Validate() []error {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	var errs []error
+
+	ids := make([]string, 0, len(bg.Nodes))
+	for id := range bg.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	froms := make([]string, 0, len(bg.Edges))
+	for from := range bg.Edges {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	inDegree := make(map[string]int)
+	for _, from := range froms {
+		if _, ok := bg.Nodes[from]; !ok {
+			errs = append(errs, fmt.Errorf("edges registered for missing node %s", from))
+		}
+		for _, edge := range bg.Edges[from] {
+			if _, ok := bg.Nodes[edge.To]; !ok {
+				errs = append(errs, fmt.Errorf("dangling edge %s->%s: target does not exist", from, edge.To))
+				continue
+			}
+			inDegree[edge.To]++
+		}
+	}
+
+	var roots []string
+	for _, id := range ids {
+		if inDegree[id] == 0 {
+			roots = append(roots, id)
+		}
+	}
+	if len(roots) == 0 && len(ids) > 0 {
+		roots = ids[:1]
+	}
+
+	reached := make(map[string]bool)
+	queue := append([]string{}, roots...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if reached[id] {
+			continue
+		}
+		reached[id] = true
+		for _, edge := range bg.Edges[id] {
+			if _, ok := bg.Nodes[edge.To]; ok && !reached[edge.To] {
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		if !reached[id] {
+			errs = append(errs, fmt.Errorf("node %s is unreachable", id))
+		}
+		if len(bg.Edges[id]) == 0 && !bg.terminal[id] {
+			errs = append(errs, fmt.Errorf("node %s has no outgoing edges", id))
+		}
+	}
+
+	return errs
+}
+
+// BuildGraph builds a graph from a node list and an adjacency list of
+// [from, to] pairs. Edges get an always-true condition and zero latency.
+// The first edge whose endpoints are missing is reported as an error.
+func BuildGraph(nodes []*BehaviorNode, edges [][2]string) (*BehaviorGraph, error) {
+	bg := NewBehaviorGraph()
+	for _, node := range nodes {
+		if node == nil {
+			return nil, fmt.Errorf("nil node")
+		}
+		if err := bg.AddNode(node); err != nil {
+			return nil, err
+		}
+	}
+	for i, e := range edges {
+		if err := bg.AddEdge(e[0], e[1], func() bool { return true }, 0, true); err != nil {
+			return nil, fmt.Errorf("edge %d (%s -> %s): %w", i, e[0], e[1], err)
+		}
+	}
+	return bg, nil
+}
+
 // ============================================================================
 // AGENT 2: State Machine Simulator
 // ============================================================================
 
+// SelectionStrategy decides which valid successor edge a StateMachine takes
+type SelectionStrategy string
+
+const (
+	// SelectFirst always takes the first valid edge
+	SelectFirst SelectionStrategy = "first"
+	// SelectHighestWeight takes the valid edge with the largest Weight,
+	// preferring the earliest on ties
+	SelectHighestWeight SelectionStrategy = "highest-weight"
+	// SelectRoundRobin cycles through a node's valid edges on repeat visits
+	SelectRoundRobin SelectionStrategy = "round-robin"
+	// SelectWeightedRandom picks a valid edge with probability proportional
+	// to its Weight, using a generator seeded from StateMachineConfig.Seed
+	SelectWeightedRandom SelectionStrategy = "weighted-random"
+)
+
 // StateMachineConfig defines the configuration for state machine execution
 type StateMachineConfig struct {
 	InitialState string
 	MaxSteps     int
 	Timeout      time.Duration
 	TrackMetrics bool
+
+	// SelectionStrategy picks among valid successors. Empty means SelectFirst.
+	SelectionStrategy SelectionStrategy
+
+	// Seed makes SelectWeightedRandom walks and probabilistic edge rolls
+	// reproducible. Zero seeds from the current time.
+	Seed int64
+
+	// SimulateTime advances a virtual clock by each edge's latency instead
+	// of sleeping, so long workflows can be analysed in milliseconds
+	SimulateTime bool
 }
 
 // StateTransition represents a single state change
@@ -133,10 +521,17 @@ type StateMachine struct {
 	visited      map[string]int
 	config       StateMachineConfig
 	startTime    time.Time
+	simulated    time.Duration // virtual time elapsed when SimulateTime is set
+	cursors      map[string]int // per-node position for SelectRoundRobin
+	rng          *rand.Rand     // drives SelectWeightedRandom and probabilistic edges
 }
 
 // NewStateMachine creates a new state machine for the behavior graph
 func NewStateMachine(bg *BehaviorGraph, config StateMachineConfig) *StateMachine {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
 	return &StateMachine{
 		graph:       bg,
 		current:     config.InitialState,
@@ -144,6 +539,74 @@ func NewStateMachine(bg *BehaviorGraph, config StateMachineConfig) *StateMachine
 		visited:     make(map[string]int),
 		config:      config,
 		startTime:   time.Now(),
+		cursors:     make(map[string]int),
+		rng:         rand.New(rand.NewSource(seed)),
+	}
+}
+
+// weightedRandomEdge picks an edge with probability proportional to its
+// Weight. Non-positive weights are never picked unless every weight is,
+// in which case the choice is uniform.
+func (sm *StateMachine) weightedRandomEdge(successors []*BehaviorEdge) *BehaviorEdge {
+	total := 0
+	for _, edge := range successors {
+		if edge.Weight > 0 {
+			total += edge.Weight
+		}
+	}
+	if total == 0 {
+		return successors[sm.rng.Intn(len(successors))]
+	}
+
+	pick := sm.rng.Intn(total)
+	for _, edge := range successors {
+		if edge.Weight <= 0 {
+			continue
+		}
+		if pick < edge.Weight {
+			return edge
+		}
+		pick -= edge.Weight
+	}
+	return successors[len(successors)-1]
+}
+
+// rollEligibility drops edges with an explicit Probability whose roll fails
+// this step, using the seeded generator. Probability 0 is never eligible
+// and probability 1 always is; plain edges are never rolled.
+func (sm *StateMachine) rollEligibility(successors []*BehaviorEdge) []*BehaviorEdge {
+	eligible := make([]*BehaviorEdge, 0, len(successors))
+	for _, edge := range successors {
+		if edge.Probabilistic && edge.Probability < 1 && sm.rng.Float64() >= edge.Probability {
+			continue
+		}
+		eligible = append(eligible, edge)
+	}
+	return eligible
+}
+
+// selectEdge picks the successor to follow according to the configured
+// selection strategy
+func (sm *StateMachine) selectEdge(successors []*BehaviorEdge) (*BehaviorEdge, error) {
+	switch sm.config.SelectionStrategy {
+	case "", SelectFirst:
+		return successors[0], nil
+	case SelectHighestWeight:
+		best := successors[0]
+		for _, edge := range successors[1:] {
+			if edge.Weight > best.Weight {
+				best = edge
+			}
+		}
+		return best, nil
+	case SelectRoundRobin:
+		cursor := sm.cursors[sm.current]
+		sm.cursors[sm.current] = cursor + 1
+		return successors[cursor%len(successors)], nil
+	case SelectWeightedRandom:
+		return sm.weightedRandomEdge(successors), nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %q", sm.config.SelectionStrategy)
 	}
 }
 
@@ -165,26 +628,40 @@ func (sm *StateMachine) Execute(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		successors = sm.rollEligibility(successors)
 
 		if len(successors) == 0 {
 			break // Dead end state
 		}
 
-		// Choose next transition (deterministically, first valid edge)
-		edge := successors[0]
+		edge, err := sm.selectEdge(successors)
+		if err != nil {
+			return err
+		}
 		startTime := time.Now()
 
-		// Simulate latency
-		if edge.Latency > 0 {
-			time.Sleep(edge.Latency)
+		var latency time.Duration
+		var timestamp time.Time
+		if sm.config.SimulateTime {
+			latency = edge.Latency
+			sm.mu.Lock()
+			sm.simulated += latency
+			timestamp = sm.startTime.Add(sm.simulated)
+			sm.mu.Unlock()
+		} else {
+			// Simulate latency
+			if edge.Latency > 0 {
+				time.Sleep(edge.Latency)
+			}
+			latency = time.Since(startTime)
+			timestamp = time.Now()
 		}
 
-		latency := time.Since(startTime)
 		sm.mu.Lock()
 		sm.transitions = append(sm.transitions, StateTransition{
 			From:      sm.current,
 			To:        edge.To,
-			Timestamp: time.Now(),
+			Timestamp: timestamp,
 			Latency:   latency,
 		})
 		sm.current = edge.To
@@ -212,7 +689,7 @@ func (sm *StateMachine) GetMetrics() map[string]interface{} {
 		avgLatency = totalLatency / time.Duration(len(sm.transitions))
 	}
 
-	return map[string]interface{}{
+	metrics := map[string]interface{}{
 		"current_state":     sm.current,
 		"transitions_count": len(sm.transitions),
 		"unique_states":     len(sm.visited),
@@ -221,6 +698,10 @@ func (sm *StateMachine) GetMetrics() map[string]interface{} {
 		"avg_latency":       avgLatency,
 		"execution_time":    time.Since(sm.startTime),
 	}
+	if sm.config.SimulateTime {
+		metrics["simulated_time"] = sm.simulated
+	}
+	return metrics
 }
 
 // ============================================================================
@@ -236,49 +717,104 @@ type BehaviorSequence struct {
 	Timestamp time.Time
 }
 
+// PathCostModel selects how a sequence's Cost is computed from its edges
+type PathCostModel string
+
+const (
+	// CostByWeight sums edge weights along the path
+	CostByWeight PathCostModel = "weight"
+	// CostByLatency sums edge latencies along the path, in microseconds
+	CostByLatency PathCostModel = "latency"
+)
+
 // PermutationGenerator generates all valid behavior sequences
 type PermutationGenerator struct {
-	mu    sync.Mutex
-	graph *BehaviorGraph
-	cache map[string][]*BehaviorSequence
+	mu        sync.Mutex
+	graph     *BehaviorGraph
+	cache     map[string][]*BehaviorSequence
+	costModel PathCostModel
 }
 
 // NewPermutationGenerator creates a new permutation generator
 func NewPermutationGenerator(bg *BehaviorGraph) *PermutationGenerator {
 	return &PermutationGenerator{
-		graph: bg,
-		cache: make(map[string][]*BehaviorSequence),
+		graph:     bg,
+		cache:     make(map[string][]*BehaviorSequence),
+		costModel: CostByWeight,
 	}
 }
 
-// GenerateSequences generates all valid behavior sequences up to maxDepth
+// SetCostModel changes how sequence costs are computed and clears the cache
+func (pg *PermutationGenerator) SetCostModel(model PathCostModel) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	pg.costModel = model
+	pg.cache = make(map[string][]*BehaviorSequence)
+}
+
+// edgeCost returns the cost of traversing edge under the generator's cost model
+func (pg *PermutationGenerator) edgeCost(edge *BehaviorEdge) int {
+	if pg.costModel == CostByLatency {
+		return int(edge.Latency / time.Microsecond)
+	}
+	return edge.Weight
+}
+
+// GenerateSequences generates all valid behavior sequences up to maxDepth.
+// Results are cached per start node and depth.
 func (pg *PermutationGenerator) GenerateSequences(startNode string, maxDepth int) ([]*BehaviorSequence, error) {
+	key := fmt.Sprintf("%s:%d", startNode, maxDepth)
+
 	pg.mu.Lock()
-	if cached, ok := pg.cache[startNode]; ok && len(cached) > 0 {
+	if cached, ok := pg.cache[key]; ok && len(cached) > 0 {
 		pg.mu.Unlock()
 		return cached, nil
 	}
 	pg.mu.Unlock()
 
 	sequences := make([]*BehaviorSequence, 0)
-	visited := make(map[string]bool)
+	pg.generateSequencesRecursive(startNode, []string{startNode}, 0, maxDepth, &sequences, nil)
+
+	pg.mu.Lock()
+	pg.cache[key] = sequences
+	pg.mu.Unlock()
+
+	return sequences, nil
+}
 
-	pg.generateSequencesRecursive(startNode, []string{startNode}, maxDepth, &sequences, visited)
+// GenerateAcyclicSequences is like GenerateSequences but only produces simple
+// paths: no node appears twice in a path, so cycles such as
+// idle -> active -> idle end a path instead of being unrolled to maxDepth.
+func (pg *PermutationGenerator) GenerateAcyclicSequences(startNode string, maxDepth int) ([]*BehaviorSequence, error) {
+	key := fmt.Sprintf("acyclic:%s:%d", startNode, maxDepth)
 
 	pg.mu.Lock()
-	pg.cache[startNode] = sequences
+	if cached, ok := pg.cache[key]; ok && len(cached) > 0 {
+		pg.mu.Unlock()
+		return cached, nil
+	}
+	pg.mu.Unlock()
+
+	sequences := make([]*BehaviorSequence, 0)
+	visited := map[string]bool{startNode: true}
+	pg.generateSequencesRecursive(startNode, []string{startNode}, 0, maxDepth, &sequences, visited)
+
+	pg.mu.Lock()
+	pg.cache[key] = sequences
 	pg.mu.Unlock()
 
 	return sequences, nil
 }
 
-func (pg *PermutationGenerator) generateSequencesRecursive(current string, path []string, depth int, sequences *[]*BehaviorSequence, visited map[string]bool) {
+// generateSequencesRecursive extends path depth more steps. When visited is
+// non-nil, nodes already on the path are not revisited.
+func (pg *PermutationGenerator) generateSequencesRecursive(current string, path []string, cost int, depth int, sequences *[]*BehaviorSequence, visited map[string]bool) {
 	if depth == 0 {
 		pathCopy := make([]string, len(path))
 		copy(pathCopy, path)
 		*sequences = append(*sequences, &BehaviorSequence{
 			Path:      pathCopy,
-			Cost:      len(pathCopy),
+			Cost:      cost,
 			Valid:     true,
 			Timestamp: time.Now(),
 		})
@@ -286,12 +822,21 @@ func (pg *PermutationGenerator) generateSequencesRecursive(current string, path
 	}
 
 	successors, err := pg.graph.GetSuccessors(current)
+	if visited != nil {
+		unvisited := make([]*BehaviorEdge, 0, len(successors))
+		for _, edge := range successors {
+			if !visited[edge.To] {
+				unvisited = append(unvisited, edge)
+			}
+		}
+		successors = unvisited
+	}
 	if err != nil || len(successors) == 0 {
 		pathCopy := make([]string, len(path))
 		copy(pathCopy, path)
 		*sequences = append(*sequences, &BehaviorSequence{
 			Path:      pathCopy,
-			Cost:      len(pathCopy),
+			Cost:      cost,
 			Valid:     true,
 			Timestamp: time.Now(),
 		})
@@ -299,9 +844,257 @@ func (pg *PermutationGenerator) generateSequencesRecursive(current string, path
 	}
 
 	for _, edge := range successors {
-		newPath := append(path, edge.To)
-		pg.generateSequencesRecursive(edge.To, newPath, depth-1, sequences, visited)
+		// Copy rather than append so sibling branches never share a backing array
+		newPath := make([]string, len(path)+1)
+		copy(newPath, path)
+		newPath[len(path)] = edge.To
+		if visited != nil {
+			visited[edge.To] = true
+		}
+		pg.generateSequencesRecursive(edge.To, newPath, cost+pg.edgeCost(edge), depth-1, sequences, visited)
+		if visited != nil {
+			delete(visited, edge.To)
+		}
+	}
+}
+
+// CheapestPath finds the path from one behavior to another with the lowest
+// total edge weight using Dijkstra's algorithm. Only edges whose conditions
+// currently hold are followed.
+func (bg *BehaviorGraph) CheapestPath(from, to string) (*BehaviorSequence, error) {
+	bg.mu.RLock()
+	_, fromOK := bg.Nodes[from]
+	_, toOK := bg.Nodes[to]
+	bg.mu.RUnlock()
+	if !fromOK {
+		return nil, fmt.Errorf("node %s does not exist", from)
+	}
+	if !toOK {
+		return nil, fmt.Errorf("node %s does not exist", to)
+	}
+
+	dist := map[string]int{from: 0}
+	prev := make(map[string]string)
+	done := make(map[string]bool)
+
+	for {
+		// Pick the closest unsettled node, breaking ties by ID for determinism
+		current, found := "", false
+		for id, d := range dist {
+			if done[id] {
+				continue
+			}
+			if !found || d < dist[current] || (d == dist[current] && id < current) {
+				current, found = id, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no path from %s to %s", from, to)
+		}
+		if current == to {
+			break
+		}
+		done[current] = true
+
+		successors, err := bg.GetSuccessors(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range successors {
+			if edge.Weight < 0 {
+				return nil, fmt.Errorf("edge %s->%s has negative weight %d", edge.From, edge.To, edge.Weight)
+			}
+			next := dist[current] + edge.Weight
+			if d, seen := dist[edge.To]; !seen || next < d {
+				dist[edge.To] = next
+				prev[edge.To] = current
+			}
+		}
+	}
+
+	path := []string{to}
+	for node := to; node != from; {
+		node = prev[node]
+		path = append([]string{node}, path...)
+	}
+
+	return &BehaviorSequence{
+		Path:      path,
+		Cost:      dist[to],
+		Valid:     true,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// ShortestPath returns the path from one behavior to another with the fewest
+// transitions, found by breadth-first search in edge order. Edge conditions
+// are ignored, so this answers whether a route exists at all; use
+// CheapestPath to follow only edges that currently hold. An unreachable
+// target gives an empty path and no error.
+func (bg *BehaviorGraph) ShortestPath(from, to string) ([]string, error) {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	if _, ok := bg.Nodes[from]; !ok {
+		return nil, fmt.Errorf("node %s does not exist", from)
 	}
+	if _, ok := bg.Nodes[to]; !ok {
+		return nil, fmt.Errorf("node %s does not exist", to)
+	}
+
+	prev := map[string]string{}
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 && !seen[to] {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range bg.Edges[current] {
+			if !seen[edge.To] {
+				seen[edge.To] = true
+				prev[edge.To] = current
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	if !seen[to] {
+		return []string{}, nil
+	}
+
+	path := []string{to}
+	for node := to; node != from; {
+		node = prev[node]
+		path = append([]string{node}, path...)
+	}
+	return path, nil
+}
+
+// StronglyConnectedComponents partitions the graph into strongly connected
+// components using Tarjan's algorithm, ignoring edge conditions. Any
+// component with more than one node is a behavior cycle. Nodes on no cycle
+// come back as singletons. Members are sorted by ID and components by their
+// first member, so results are deterministic.
+func (bg *BehaviorGraph) StronglyConnectedComponents() [][]string {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	ids := make([]string, 0, len(bg.Nodes))
+	for id := range bg.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	index := 0
+	indices := make(map[string]int, len(ids))
+	lowlink := make(map[string]int, len(ids))
+	onStack := make(map[string]bool, len(ids))
+	var stack []string
+	var components [][]string
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, edge := range bg.Edges[id] {
+			if _, visited := indices[edge.To]; !visited {
+				strongConnect(edge.To)
+				if lowlink[edge.To] < lowlink[id] {
+					lowlink[id] = lowlink[edge.To]
+				}
+			} else if onStack[edge.To] && indices[edge.To] < lowlink[id] {
+				lowlink[id] = indices[edge.To]
+			}
+		}
+
+		if lowlink[id] == indices[id] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == id {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range ids {
+		if _, visited := indices[id]; !visited {
+			strongConnect(id)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// DeadEndNodes returns the sorted IDs of nodes with no outgoing edges,
+// skipping nodes marked terminal
+func (bg *BehaviorGraph) DeadEndNodes() []string {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	var deadEnds []string
+	for id := range bg.Nodes {
+		if len(bg.Edges[id]) == 0 && !bg.terminal[id] {
+			deadEnds = append(deadEnds, id)
+		}
+	}
+	sort.Strings(deadEnds)
+	return deadEnds
+}
+
+// UnreachableNodes returns the sorted IDs of nodes that cannot be reached
+// from the given start, ignoring edge conditions. Terminal nodes are still
+// reported: being an intended end says nothing about whether a behavior can
+// get there. Every node is unreachable from a start that does not exist.
+func (bg *BehaviorGraph) UnreachableNodes(from string) []string {
+	reached := bg.Reachable(from)
+
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	var unreachable []string
+	for id := range bg.Nodes {
+		if !reached[id] {
+			unreachable = append(unreachable, id)
+		}
+	}
+	sort.Strings(unreachable)
+	return unreachable
+}
+
+// Reachable returns every node reachable from the given node, including
+// itself, ignoring edge conditions. It is empty if the node does not exist.
+func (bg *BehaviorGraph) Reachable(from string) map[string]bool {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	reached := make(map[string]bool)
+	if _, ok := bg.Nodes[from]; !ok {
+		return reached
+	}
+
+	reached[from] = true
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range bg.Edges[current] {
+			if !reached[edge.To] {
+				reached[edge.To] = true
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	return reached
 }
 
 // ============================================================================
@@ -449,6 +1242,92 @@ func (ce *ConcurrentExecutor) ExecuteAll(ctx context.Context, sequences []*Behav
 	return results, nil
 }
 
+// StepResult records the outcome of one node in an executed sequence
+type StepResult struct {
+	Index   int
+	NodeID  string
+	Success bool
+	Latency time.Duration
+	Error   error
+}
+
+// SequenceExecutionResult reports what happened at each step of a sequence
+type SequenceExecutionResult struct {
+	Path       []string
+	Steps      []StepResult
+	Success    bool
+	FailedStep int // index into Steps of the failing step, or -1
+	Duration   time.Duration
+}
+
+// ExecuteSequence walks seq.Path in order, calling stepFn for each node and
+// recording per-step success and latency. It stops at the first step whose
+// node is missing, whose transition is not an edge in the graph, or whose
+// stepFn returns an error; that failure is reported in the result rather
+// than returned. An error is returned only for an empty sequence or when ctx
+// is cancelled.
+func (bg *BehaviorGraph) ExecuteSequence(ctx context.Context, seq *BehaviorSequence, stepFn func(node string) error) (*SequenceExecutionResult, error) {
+	if seq == nil || len(seq.Path) == 0 {
+		return nil, fmt.Errorf("sequence has no steps")
+	}
+	if stepFn == nil {
+		return nil, fmt.Errorf("step function is nil")
+	}
+
+	result := &SequenceExecutionResult{
+		Path:       append([]string(nil), seq.Path...),
+		Steps:      make([]StepResult, 0, len(seq.Path)),
+		Success:    true,
+		FailedStep: -1,
+	}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	for i, nodeID := range seq.Path {
+		if err := ctx.Err(); err != nil {
+			result.Success = false
+			return result, err
+		}
+
+		step := StepResult{Index: i, NodeID: nodeID}
+		stepStart := time.Now()
+		step.Error = bg.checkStep(seq.Path, i)
+		if step.Error == nil {
+			step.Error = stepFn(nodeID)
+		}
+		step.Latency = time.Since(stepStart)
+		step.Success = step.Error == nil
+		result.Steps = append(result.Steps, step)
+
+		if !step.Success {
+			result.Success = false
+			result.FailedStep = i
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// checkStep verifies that path[i] exists and is reachable from path[i-1]
+func (bg *BehaviorGraph) checkStep(path []string, i int) error {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	if _, exists := bg.Nodes[path[i]]; !exists {
+		return fmt.Errorf("node %s does not exist", path[i])
+	}
+	if i == 0 {
+		return nil
+	}
+	for _, edge := range bg.Edges[path[i-1]] {
+		if edge.To == path[i] {
+			return nil
+		}
+	}
+	return fmt.Errorf("no edge from %s to %s", path[i-1], path[i])
+}
+
 // ============================================================================
 // AGENT 6: Coverage Analyzer
 // ============================================================================
@@ -458,10 +1337,25 @@ type CoverageReport struct {
 	TotalNodes        int
 	VisitedNodes      int
 	CoveragePercent   float64
+	WeightedCoveragePercent float64 // Coverage with nodes weighted by Metadata["coverage_weight"]
 	UncoveredNodes    []string
 	EdgeCoverage      map[string]int
 	SequenceCoverage  float64
 	Timestamp         time.Time
+
+	// TotalEdges counts distinct from->to edges in the graph
+	TotalEdges int
+	// EdgeCoveragePercent is the share of graph edges traversed at least once
+	EdgeCoveragePercent float64
+	// UncoveredEdges lists graph edges never traversed, as "from->to"
+	UncoveredEdges []string
+
+	// TotalPaths counts the distinct target paths set on the analyzer
+	TotalPaths int
+	// PathCoveragePercent is the share of target paths recorded at least once
+	PathCoveragePercent float64
+	// MissingPaths lists target paths never recorded, as "a->b->c"
+	MissingPaths []string
 }
 
 // CoverageAnalyzer analyzes behavior space coverage
@@ -470,17 +1364,49 @@ type CoverageAnalyzer struct {
 	graph         *BehaviorGraph
 	visitedNodes  map[string]int
 	edgeCoverage  map[string]map[string]int
+	targetPaths   []string // distinct path keys, in the order set
+	recordedPaths map[string]int
 }
 
 // NewCoverageAnalyzer creates a new coverage analyzer
 func NewCoverageAnalyzer(bg *BehaviorGraph) *CoverageAnalyzer {
 	return &CoverageAnalyzer{
 		graph:        bg,
-		visitedNodes: make(map[string]int),
-		edgeCoverage: make(map[string]map[string]int),
+		visitedNodes:  make(map[string]int),
+		edgeCoverage:  make(map[string]map[string]int),
+		recordedPaths: make(map[string]int),
 	}
 }
 
+// pathKey renders a path as "a->b->c"
+func pathKey(path []string) string {
+	return strings.Join(path, "->")
+}
+
+// SetTargetPaths sets the paths, such as those from a PermutationGenerator,
+// that path coverage is measured against. Duplicates are ignored.
+func (ca *CoverageAnalyzer) SetTargetPaths(paths [][]string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	seen := make(map[string]bool, len(paths))
+	ca.targetPaths = make([]string, 0, len(paths))
+	for _, path := range paths {
+		key := pathKey(path)
+		if !seen[key] {
+			seen[key] = true
+			ca.targetPaths = append(ca.targetPaths, key)
+		}
+	}
+}
+
+// RecordPath records that a full behavior sequence was executed
+func (ca *CoverageAnalyzer) RecordPath(path []string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.recordedPaths[pathKey(path)]++
+}
+
 // RecordVisit records a node visit
 func (ca *CoverageAnalyzer) RecordVisit(nodeID string) {
 	ca.mu.Lock()
@@ -519,6 +1445,7 @@ func (ca *CoverageAnalyzer) GenerateReport() *CoverageReport {
 	}
 
 	report.CoveragePercent = float64(report.VisitedNodes) / float64(report.TotalNodes) * 100
+	report.WeightedCoveragePercent = weightedCoverage(ca.graph.Nodes, ca.visitedNodes)
 
 	totalEdges := 0
 	totalTransitions := 0
@@ -536,9 +1463,246 @@ func (ca *CoverageAnalyzer) GenerateReport() *CoverageReport {
 		report.SequenceCoverage = float64(totalTransitions) / float64(totalEdges)
 	}
 
+	ca.edgeCoverageLocked(report)
+
+	report.TotalPaths = len(ca.targetPaths)
+	report.MissingPaths = make([]string, 0)
+	for _, key := range ca.targetPaths {
+		if ca.recordedPaths[key] == 0 {
+			report.MissingPaths = append(report.MissingPaths, key)
+		}
+	}
+	if report.TotalPaths > 0 {
+		report.PathCoveragePercent = float64(report.TotalPaths-len(report.MissingPaths)) / float64(report.TotalPaths) * 100
+	}
+
 	return report
 }
 
+// edgeCoverageLocked fills in the report's graph edge coverage. The caller
+// holds ca.mu.
+func (ca *CoverageAnalyzer) edgeCoverageLocked(report *CoverageReport) {
+	ca.graph.mu.RLock()
+	defer ca.graph.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	covered := 0
+	report.UncoveredEdges = make([]string, 0)
+	for from, edges := range ca.graph.Edges {
+		for _, edge := range edges {
+			key := fmt.Sprintf("%s->%s", from, edge.To)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if ca.edgeCoverage[from][edge.To] > 0 {
+				covered++
+			} else {
+				report.UncoveredEdges = append(report.UncoveredEdges, key)
+			}
+		}
+	}
+	sort.Strings(report.UncoveredEdges)
+
+	report.TotalEdges = len(seen)
+	if report.TotalEdges > 0 {
+		report.EdgeCoveragePercent = float64(covered) / float64(report.TotalEdges) * 100
+	}
+}
+
+// defaultCoverageWeight applies to nodes without a coverage_weight
+const defaultCoverageWeight = 1.0
+
+// coverageWeight returns a node's Metadata["coverage_weight"], or the
+// default when it is missing, non-numeric or negative
+func coverageWeight(node *BehaviorNode) float64 {
+	if node == nil || node.Metadata == nil {
+		return defaultCoverageWeight
+	}
+	var weight float64
+	switch v := node.Metadata["coverage_weight"].(type) {
+	case float64:
+		weight = v
+	case float32:
+		weight = float64(v)
+	case int:
+		weight = float64(v)
+	default:
+		return defaultCoverageWeight
+	}
+	if weight < 0 {
+		return defaultCoverageWeight
+	}
+	return weight
+}
+
+// weightedCoverage returns the visited share of total node weight, as a
+// percentage
+func weightedCoverage(nodes map[string]*BehaviorNode, visited map[string]int) float64 {
+	var covered, total float64
+	for id, node := range nodes {
+		weight := coverageWeight(node)
+		total += weight
+		if _, ok := visited[id]; ok {
+			covered += weight
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total * 100
+}
+
+// MergeCoverageReports combines reports from runs over the same graph. A node
+// counts as visited if any run visited it, and edge counts are summed. All
+// reports must share the same total node count. Reports don't carry node
+// weights, so WeightedCoveragePercent is left zero; recompute it from a
+// CoverageAnalyzer over the graph if needed.
+func MergeCoverageReports(reports ...*CoverageReport) (*CoverageReport, error) {
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no coverage reports to merge")
+	}
+
+	merged := &CoverageReport{
+		TotalNodes:   reports[0].TotalNodes,
+		TotalEdges:   reports[0].TotalEdges,
+		TotalPaths:   reports[0].TotalPaths,
+		EdgeCoverage: make(map[string]int),
+		Timestamp:    time.Now(),
+	}
+
+	// A node, edge or path stays uncovered only if every report left it uncovered
+	uncoveredCount := make(map[string]int)
+	uncoveredEdgeCount := make(map[string]int)
+	missingPathCount := make(map[string]int)
+	for i, report := range reports {
+		if report == nil {
+			return nil, fmt.Errorf("coverage report %d is nil", i)
+		}
+		if report.TotalNodes != merged.TotalNodes {
+			return nil, fmt.Errorf("coverage report %d covers %d nodes, expected %d (different graphs?)",
+				i, report.TotalNodes, merged.TotalNodes)
+		}
+		if report.TotalEdges != merged.TotalEdges {
+			return nil, fmt.Errorf("coverage report %d covers %d edges, expected %d (different graphs?)",
+				i, report.TotalEdges, merged.TotalEdges)
+		}
+		for _, nodeID := range report.UncoveredNodes {
+			uncoveredCount[nodeID]++
+		}
+		if report.TotalPaths != merged.TotalPaths {
+			return nil, fmt.Errorf("coverage report %d targets %d paths, expected %d",
+				i, report.TotalPaths, merged.TotalPaths)
+		}
+		for _, edge := range report.UncoveredEdges {
+			uncoveredEdgeCount[edge]++
+		}
+		for _, path := range report.MissingPaths {
+			missingPathCount[path]++
+		}
+		for edge, count := range report.EdgeCoverage {
+			merged.EdgeCoverage[edge] += count
+		}
+	}
+
+	merged.UncoveredNodes = make([]string, 0)
+	for nodeID, count := range uncoveredCount {
+		if count == len(reports) {
+			merged.UncoveredNodes = append(merged.UncoveredNodes, nodeID)
+		}
+	}
+	sort.Strings(merged.UncoveredNodes)
+
+	merged.VisitedNodes = merged.TotalNodes - len(merged.UncoveredNodes)
+	if merged.TotalNodes > 0 {
+		merged.CoveragePercent = float64(merged.VisitedNodes) / float64(merged.TotalNodes) * 100
+	}
+
+	totalTransitions := 0
+	for _, count := range merged.EdgeCoverage {
+		totalTransitions += count
+	}
+	if len(merged.EdgeCoverage) > 0 {
+		merged.SequenceCoverage = float64(totalTransitions) / float64(len(merged.EdgeCoverage))
+	}
+
+	merged.UncoveredEdges = make([]string, 0)
+	for edge, count := range uncoveredEdgeCount {
+		if count == len(reports) {
+			merged.UncoveredEdges = append(merged.UncoveredEdges, edge)
+		}
+	}
+	sort.Strings(merged.UncoveredEdges)
+	if merged.TotalEdges > 0 {
+		merged.EdgeCoveragePercent = float64(merged.TotalEdges-len(merged.UncoveredEdges)) / float64(merged.TotalEdges) * 100
+	}
+
+	merged.MissingPaths = make([]string, 0)
+	for path, count := range missingPathCount {
+		if count == len(reports) {
+			merged.MissingPaths = append(merged.MissingPaths, path)
+		}
+	}
+	sort.Strings(merged.MissingPaths)
+	if merged.TotalPaths > 0 {
+		merged.PathCoveragePercent = float64(merged.TotalPaths-len(merged.MissingPaths)) / float64(merged.TotalPaths) * 100
+	}
+
+	return merged, nil
+}
+
+// heatmapWidth is the number of cells in each CoverageHeatmap bar
+const heatmapWidth = 20
+
+// heatmapSymbol buckets a coverage percentage into the symbol used to fill
+// its bar, so under-tested graphs stand out without terminal colors
+func heatmapSymbol(percent float64) string {
+	switch {
+	case percent >= 80:
+		return "#"
+	case percent >= 50:
+		return "+"
+	default:
+		return "-"
+	}
+}
+
+// CoverageHeatmap renders one row per named coverage report, sorted by name,
+// with a bar whose fill symbol reflects the coverage bucket. Nil reports are
+// shown as having no coverage.
+func CoverageHeatmap(named map[string]*CoverageReport) string {
+	names := make([]string, 0, len(named))
+	nameWidth := 0
+	for name := range named {
+		names = append(names, name)
+		if len(name) > nameWidth {
+			nameWidth = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("Coverage heatmap (# >= 80%, + >= 50%, - < 50%)\n")
+	for _, name := range names {
+		percent := 0.0
+		if report := named[name]; report != nil {
+			percent = report.CoveragePercent
+		}
+
+		filled := int(percent / 100 * heatmapWidth)
+		if filled > heatmapWidth {
+			filled = heatmapWidth
+		}
+		if filled < 0 {
+			filled = 0
+		}
+		bar := strings.Repeat(heatmapSymbol(percent), filled) + strings.Repeat(" ", heatmapWidth-filled)
+
+		fmt.Fprintf(&sb, "%-*s [%s] %5.1f%%\n", nameWidth, name, bar, percent)
+	}
+	return sb.String()
+}
+
 // ============================================================================
 // AGENT 7: Performance Profiler
 // ============================================================================
@@ -555,19 +1719,154 @@ type PerformanceMetrics struct {
 	MemoryUsage      uint64
 	GoroutineCount   int
 	Timestamp        time.Time
+	Buckets          []LatencyBucket
+	StdDevLatency    time.Duration
+	Histogram        map[string]int // bucket label -> count, from Buckets
+}
+
+// LatencyBucket counts executions whose latency falls in (LowerBound,
+// UpperBound]. The last bucket has a zero UpperBound and catches everything
+// above the largest boundary.
+type LatencyBucket struct {
+	LowerBound time.Duration
+	UpperBound time.Duration
+	Count      int
+}
+
+// DefaultLatencyBuckets are the bucket upper bounds used by
+// NewPerformanceProfiler
+var DefaultLatencyBuckets = []time.Duration{
+	1 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	5 * time.Second,
 }
 
 // PerformanceProfiler measures execution performance
 type PerformanceProfiler struct {
-	mu      sync.RWMutex
-	metrics []*PerformanceMetrics
+	mu           sync.RWMutex
+	metrics      []*PerformanceMetrics
+	bucketBounds []time.Duration
+
+	// CaptureRuntimeStats records heap allocation and goroutine count with
+	// each recording. It is on by default; turn it off to skip the cost of
+	// runtime.ReadMemStats.
+	CaptureRuntimeStats bool
 }
 
 // NewPerformanceProfiler creates a new performance profiler
 func NewPerformanceProfiler() *PerformanceProfiler {
+	return NewPerformanceProfilerWithBuckets(DefaultLatencyBuckets)
+}
+
+// NewPerformanceProfilerWithBuckets creates a performance profiler that
+// histograms latencies using the given bucket upper bounds. Bounds are
+// sorted and de-duplicated; an overflow bucket is always added.
+func NewPerformanceProfilerWithBuckets(bounds []time.Duration) *PerformanceProfiler {
 	return &PerformanceProfiler{
-		metrics: make([]*PerformanceMetrics, 0),
+		metrics:             make([]*PerformanceMetrics, 0),
+		bucketBounds:        normalizeBucketBounds(bounds),
+		CaptureRuntimeStats: true,
+	}
+}
+
+// captureRuntimeStats fills in memory and goroutine figures when enabled
+func (pp *PerformanceProfiler) captureRuntimeStats(metrics *PerformanceMetrics) {
+	if !pp.CaptureRuntimeStats {
+		return
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	metrics.MemoryUsage = mem.Alloc
+	metrics.GoroutineCount = runtime.NumGoroutine()
+}
+
+// WithBuckets replaces the profiler's bucket upper bounds for future
+// recordings and returns the profiler for chaining
+func (pp *PerformanceProfiler) WithBuckets(bounds []time.Duration) *PerformanceProfiler {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.bucketBounds = normalizeBucketBounds(bounds)
+	return pp
+}
+
+// normalizeBucketBounds sorts bounds and drops duplicates and non-positive
+// values
+func normalizeBucketBounds(bounds []time.Duration) []time.Duration {
+	sorted := append([]time.Duration(nil), bounds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	unique := make([]time.Duration, 0, len(sorted))
+	for i, b := range sorted {
+		if b <= 0 || (i > 0 && b == sorted[i-1]) {
+			continue
+		}
+		unique = append(unique, b)
+	}
+	return unique
+}
+
+// Label names the bucket's range, e.g. "<=1ms", "1ms-10ms" or ">100ms"
+func (b LatencyBucket) Label() string {
+	switch {
+	case b.UpperBound == 0:
+		return ">" + b.LowerBound.String()
+	case b.LowerBound == 0:
+		return "<=" + b.UpperBound.String()
+	default:
+		return b.LowerBound.String() + "-" + b.UpperBound.String()
+	}
+}
+
+// histogram maps each bucket's label to its count
+func histogram(buckets []LatencyBucket) map[string]int {
+	h := make(map[string]int, len(buckets))
+	for _, b := range buckets {
+		h[b.Label()] = b.Count
+	}
+	return h
+}
+
+// latencyStdDev returns the population standard deviation of latencies
+func latencyStdDev(latencies []time.Duration, mean time.Duration) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	var sumSquares float64
+	for _, l := range latencies {
+		d := float64(l - mean)
+		sumSquares += d * d
 	}
+	return time.Duration(math.Sqrt(sumSquares / float64(len(latencies))))
+}
+
+// newBuckets returns an empty histogram for the profiler's boundaries
+func (pp *PerformanceProfiler) newBuckets() []LatencyBucket {
+	buckets := make([]LatencyBucket, 0, len(pp.bucketBounds)+1)
+	lower := time.Duration(0)
+	for _, upper := range pp.bucketBounds {
+		buckets = append(buckets, LatencyBucket{LowerBound: lower, UpperBound: upper})
+		lower = upper
+	}
+	return append(buckets, LatencyBucket{LowerBound: lower})
+}
+
+// bucketLatencies counts each latency into its histogram bucket
+func (pp *PerformanceProfiler) bucketLatencies(latencies []time.Duration) []LatencyBucket {
+	buckets := pp.newBuckets()
+	for _, latency := range latencies {
+		idx := sort.Search(len(pp.bucketBounds), func(i int) bool {
+			return latency <= pp.bucketBounds[i]
+		})
+		buckets[idx].Count++
+	}
+	return buckets
 }
 
 // RecordExecution records execution metrics
@@ -576,7 +1875,10 @@ func (pp *PerformanceProfiler) RecordExecution(results []*ExecutionResult) *Perf
 	defer pp.mu.Unlock()
 
 	if len(results) == 0 {
-		return &PerformanceMetrics{Timestamp: time.Now()}
+		buckets := pp.newBuckets()
+		metrics := &PerformanceMetrics{Timestamp: time.Now(), Buckets: buckets, Histogram: histogram(buckets)}
+		pp.captureRuntimeStats(metrics)
+		return metrics
 	}
 
 	latencies := make([]time.Duration, 0)
@@ -596,21 +1898,43 @@ func (pp *PerformanceProfiler) RecordExecution(results []*ExecutionResult) *Perf
 		}
 	}
 
+	avg := totalDuration / time.Duration(len(results))
+	buckets := pp.bucketLatencies(latencies)
 	metrics := &PerformanceMetrics{
 		MinLatency:    latencies[0],
 		MaxLatency:    latencies[len(latencies)-1],
-		AvgLatency:    totalDuration / time.Duration(len(results)),
-		P95Latency:    latencies[int(float64(len(latencies))*0.95)],
-		P99Latency:    latencies[int(float64(len(latencies))*0.99)],
+		AvgLatency:    avg,
+		StdDevLatency: latencyStdDev(latencies, avg),
+		P95Latency:    latencyPercentile(latencies, 95),
+		P99Latency:    latencyPercentile(latencies, 99),
 		Throughput:    float64(len(results)) / totalDuration.Seconds(),
 		TotalDuration: totalDuration,
 		Timestamp:     time.Now(),
+		Buckets:       buckets,
+		Histogram:     histogram(buckets),
 	}
+	pp.captureRuntimeStats(metrics)
 
 	pp.metrics = append(pp.metrics, metrics)
 	return metrics
 }
 
+// latencyPercentile returns the nearest-rank percentile p of sorted latencies,
+// clamped to the sample range
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
 // GetAverageMetrics returns average metrics across all recordings
 func (pp *PerformanceProfiler) GetAverageMetrics() *PerformanceMetrics {
 	pp.mu.RLock()
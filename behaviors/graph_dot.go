@@ -0,0 +1,79 @@
+package behaviors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToDOT renders the graph in Graphviz DOT. Nodes are labeled with their
+// Name, edges with their latency, and non-deterministic edges are dashed.
+func (bg *BehaviorGraph) ToDOT() string {
+	return bg.ToDOTWithCoverage(nil)
+}
+
+// ToDOTWithCoverage renders the graph like ToDOT, filling visited nodes
+// green and drawing uncovered nodes and edges in red. A nil report adds no
+// coloring.
+func (bg *BehaviorGraph) ToDOTWithCoverage(report *CoverageReport) string {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	uncoveredNodes := make(map[string]bool)
+	uncoveredEdges := make(map[string]bool)
+	if report != nil {
+		for _, id := range report.UncoveredNodes {
+			uncoveredNodes[id] = true
+		}
+		for _, edge := range report.UncoveredEdges {
+			uncoveredEdges[edge] = true
+		}
+	}
+
+	ids := make([]string, 0, len(bg.Nodes))
+	for id := range bg.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	b.WriteString("digraph behaviors {\n")
+	for _, id := range ids {
+		label := bg.Nodes[id].Name
+		if label == "" {
+			label = id
+		}
+		attrs := []string{"label=" + dotQuote(label)}
+		if report != nil {
+			if uncoveredNodes[id] {
+				attrs = append(attrs, `style=filled`, `fillcolor="lightcoral"`)
+			} else {
+				attrs = append(attrs, `style=filled`, `fillcolor="palegreen"`)
+			}
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(id), strings.Join(attrs, ", "))
+	}
+	for _, id := range ids {
+		for _, edge := range bg.Edges[id] {
+			attrs := []string{"label=" + dotQuote(edge.Latency.String())}
+			if !edge.Deterministic {
+				attrs = append(attrs, "style=dashed")
+			}
+			if uncoveredEdges[edge.From+"->"+edge.To] {
+				attrs = append(attrs, `color="red"`)
+			}
+			fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscaper escapes the characters that are special inside a DOT quoted ID
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// dotQuote renders s as a double-quoted DOT ID. Unlike strconv.Quote it
+// leaves non-ASCII text as is, since Graphviz reads UTF-8 directly.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,73 @@
+package behaviors
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestToDOT verifies the DOT export declares the graph, its nodes and edges
+func TestToDOT(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	if err := graph.AddProbabilisticEdge("degraded", "shutdown", 0.5, 0); err != nil {
+		t.Fatalf("AddProbabilisticEdge failed: %v", err)
+	}
+
+	dot := graph.ToDOT()
+	if !strings.HasPrefix(dot, "digraph behaviors {") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("Expected a digraph block, got:\n%s", dot)
+	}
+	if !strings.Contains(dot, `"idle" -> "active"`) {
+		t.Errorf("Expected idle -> active edge, got:\n%s", dot)
+	}
+	if !strings.Contains(dot, `"degraded" -> "shutdown" [label="0s", style=dashed]`) {
+		t.Errorf("Expected dashed non-deterministic edge, got:\n%s", dot)
+	}
+	if got := strings.Count(dot, " -> "); got != countEdges(graph) {
+		t.Errorf("Expected %d edge lines, got %d", countEdges(graph), got)
+	}
+	if strings.Contains(dot, "fillcolor") {
+		t.Error("Expected no coverage coloring without a report")
+	}
+}
+
+// TestToDOTWithCoverage verifies coverage coloring of nodes and edges
+func TestToDOTWithCoverage(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	report := &CoverageReport{
+		UncoveredNodes: []string{"shutdown"},
+		UncoveredEdges: []string{"busy->shutdown"},
+	}
+
+	dot := graph.ToDOTWithCoverage(report)
+	if !strings.Contains(dot, `"shutdown" [label=`) || !strings.Contains(dot, `fillcolor="lightcoral"`) {
+		t.Errorf("Expected shutdown colored as uncovered, got:\n%s", dot)
+	}
+	for _, line := range strings.Split(dot, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), `"busy" -> "shutdown"`) && !strings.Contains(line, `color="red"`) {
+			t.Errorf("Expected uncovered edge in red, got %q", line)
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), `"idle" [`) && !strings.Contains(line, "palegreen") {
+			t.Errorf("Expected idle colored as visited, got %q", line)
+		}
+	}
+}
+
+// TestToDOTEscapesIDs verifies quotes and backslashes are escaped while
+// UTF-8 labels are written unchanged
+func TestToDOTEscapesIDs(t *testing.T) {
+	graph := NewBehaviorGraph()
+	graph.AddNode(&BehaviorNode{ID: `say "hi"`, Name: "café ☕"})
+	graph.AddNode(&BehaviorNode{ID: `C:\temp`, Name: `C:\temp`})
+	graph.AddEdge(`say "hi"`, `C:\temp`, nil, 0, true)
+
+	dot := graph.ToDOT()
+	for _, want := range []string{
+		`"say \"hi\"" [label="café ☕"];`,
+		`"C:\\temp" [label="C:\\temp"];`,
+		`"say \"hi\"" -> "C:\\temp"`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Expected %s in:\n%s", want, dot)
+		}
+	}
+}
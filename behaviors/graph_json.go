@@ -0,0 +1,146 @@
+package behaviors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// graphJSON is the serializable topology of a BehaviorGraph. Edge
+// conditions are functions, so they are not persisted.
+type graphJSON struct {
+	Nodes []nodeJSON `json:"nodes"`
+	Edges []edgeJSON `json:"edges"`
+}
+
+// nodeJSON is the serializable form of a BehaviorNode
+type nodeJSON struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category,omitempty"`
+	Constraints []string `json:"constraints,omitempty"`
+	Terminal    bool     `json:"terminal,omitempty"`
+}
+
+// edgeJSON is the serializable form of a BehaviorEdge. Latency uses
+// time.Duration string syntax such as "10ms". A missing weight means 1.
+type edgeJSON struct {
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Weight        *int     `json:"weight,omitempty"`
+	Latency       string   `json:"latency,omitempty"`
+	Deterministic bool     `json:"deterministic,omitempty"`
+	Probability   *float64 `json:"probability,omitempty"`
+}
+
+// MarshalJSON serializes the graph's nodes, sorted by ID, and its edges,
+// grouped by source node in their original order
+func (bg *BehaviorGraph) MarshalJSON() ([]byte, error) {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	ids := make([]string, 0, len(bg.Nodes))
+	for id := range bg.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	dto := graphJSON{
+		Nodes: make([]nodeJSON, 0, len(ids)),
+		Edges: make([]edgeJSON, 0),
+	}
+	for _, id := range ids {
+		node := bg.Nodes[id]
+		dto.Nodes = append(dto.Nodes, nodeJSON{
+			ID:          node.ID,
+			Name:        node.Name,
+			Category:    node.Category,
+			Constraints: node.Constraints,
+			Terminal:    bg.terminal[id],
+		})
+		for _, edge := range bg.Edges[id] {
+			weight := edge.Weight
+			e := edgeJSON{
+				From:          edge.From,
+				To:            edge.To,
+				Weight:        &weight,
+				Deterministic: edge.Deterministic,
+			}
+			if edge.Latency > 0 {
+				e.Latency = edge.Latency.String()
+			}
+			if edge.Probabilistic {
+				prob := edge.Probability
+				e.Probability = &prob
+			}
+			dto.Edges = append(dto.Edges, e)
+		}
+	}
+	return json.Marshal(dto)
+}
+
+// LoadBehaviorGraph builds a graph from JSON as produced by MarshalJSON.
+// Every edge gets an always-true condition; edges with a probability are
+// loaded as probabilistic edges.
+func LoadBehaviorGraph(data []byte) (*BehaviorGraph, error) {
+	var dto graphJSON
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("invalid behavior graph JSON: %w", err)
+	}
+
+	bg := NewBehaviorGraph()
+	for i, n := range dto.Nodes {
+		if n.ID == "" {
+			return nil, fmt.Errorf("node %d has no ID", i)
+		}
+		node := &BehaviorNode{
+			ID:          n.ID,
+			Name:        n.Name,
+			Category:    n.Category,
+			Constraints: n.Constraints,
+		}
+		if err := bg.AddNode(node); err != nil {
+			return nil, err
+		}
+		if n.Terminal {
+			bg.MarkTerminal(n.ID)
+		}
+	}
+
+	for i, e := range dto.Edges {
+		if _, exists := bg.Nodes[e.From]; !exists {
+			return nil, fmt.Errorf("edge %d (%s -> %s): source node %s does not exist", i, e.From, e.To, e.From)
+		}
+		if _, exists := bg.Nodes[e.To]; !exists {
+			return nil, fmt.Errorf("edge %d (%s -> %s): target node %s does not exist", i, e.From, e.To, e.To)
+		}
+
+		edge := &BehaviorEdge{
+			From:          e.From,
+			To:            e.To,
+			Condition:     func() bool { return true },
+			Weight:        1,
+			Deterministic: e.Deterministic,
+		}
+		if e.Weight != nil {
+			edge.Weight = *e.Weight
+		}
+		if e.Latency != "" {
+			latency, err := time.ParseDuration(e.Latency)
+			if err != nil {
+				return nil, fmt.Errorf("edge %d (%s -> %s): invalid latency: %w", i, e.From, e.To, err)
+			}
+			edge.Latency = latency
+		}
+		if e.Probability != nil {
+			if *e.Probability < 0 || *e.Probability > 1 {
+				return nil, fmt.Errorf("edge %d (%s -> %s): probability %v is outside [0, 1]", i, e.From, e.To, *e.Probability)
+			}
+			edge.Probability = *e.Probability
+			edge.Probabilistic = true
+		}
+		bg.Edges[e.From] = append(bg.Edges[e.From], edge)
+	}
+	return bg, nil
+}
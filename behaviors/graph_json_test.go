@@ -0,0 +1,98 @@
+package behaviors
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBehaviorGraphJSONRoundTrip verifies marshaling and reloading keeps the
+// graph's topology and edge fields
+func TestBehaviorGraphJSONRoundTrip(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	graph.Nodes["idle"].Constraints = []string{"rate_limit"}
+	graph.MarkTerminal("shutdown")
+	if err := graph.AddProbabilisticEdge("degraded", "shutdown", 0.25, 5*time.Millisecond); err != nil {
+		t.Fatalf("AddProbabilisticEdge failed: %v", err)
+	}
+
+	data, err := graph.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	loaded, err := LoadBehaviorGraph(data)
+	if err != nil {
+		t.Fatalf("LoadBehaviorGraph failed: %v", err)
+	}
+
+	if len(loaded.Nodes) != len(graph.Nodes) {
+		t.Errorf("Expected %d nodes, got %d", len(graph.Nodes), len(loaded.Nodes))
+	}
+	if got, want := countEdges(loaded), countEdges(graph); got != want {
+		t.Errorf("Expected %d edges, got %d", want, got)
+	}
+	if dump, want := graphSignature(loaded), graphSignature(graph); dump != want {
+		t.Errorf("Expected identical graphs\nwant: %s\ngot:  %s", want, dump)
+	}
+
+	if !loaded.terminal["shutdown"] || len(loaded.terminal) != 1 {
+		t.Errorf("Expected only shutdown to load as terminal, got %v", loaded.terminal)
+	}
+
+	for id := range graph.Nodes {
+		want, _ := graph.GetSuccessors(id)
+		got, err := loaded.GetSuccessors(id)
+		if err != nil {
+			t.Fatalf("GetSuccessors(%s) failed: %v", id, err)
+		}
+		if edgeTargets(got) != edgeTargets(want) {
+			t.Errorf("Successors of %s: expected %s, got %s", id, edgeTargets(want), edgeTargets(got))
+		}
+	}
+
+	last := loaded.Edges["degraded"][len(loaded.Edges["degraded"])-1]
+	if !last.Probabilistic || last.Probability != 0.25 {
+		t.Errorf("Expected probabilistic edge with probability 0.25, got %+v", last)
+	}
+}
+
+// TestLoadBehaviorGraphErrors verifies malformed graphs are rejected
+func TestLoadBehaviorGraphErrors(t *testing.T) {
+	cases := map[string]string{
+		"bad json":       `{"nodes": [`,
+		"missing target": `{"nodes": [{"id": "a"}], "edges": [{"from": "a", "to": "b"}]}`,
+		"bad latency":    `{"nodes": [{"id": "a"}], "edges": [{"from": "a", "to": "a", "latency": "soon"}]}`,
+		"duplicate node": `{"nodes": [{"id": "a"}, {"id": "a"}]}`,
+	}
+	for name, data := range cases {
+		if _, err := LoadBehaviorGraph([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	graph, err := LoadBehaviorGraph([]byte(`{"nodes": [{"id": "a"}, {"id": "b"}], "edges": [{"from": "a", "to": "b"}]}`))
+	if err != nil {
+		t.Fatalf("LoadBehaviorGraph failed: %v", err)
+	}
+	if edge := graph.Edges["a"][0]; edge.Weight != 1 || edge.Condition == nil || !edge.Condition() {
+		t.Errorf("Expected default weight 1 and an always-true condition, got %+v", edge)
+	}
+}
+
+// countEdges returns the total number of edges in a graph
+func countEdges(bg *BehaviorGraph) int {
+	total := 0
+	for _, edges := range bg.Edges {
+		total += len(edges)
+	}
+	return total
+}
+
+// edgeTargets renders a successor list as its target IDs in order
+func edgeTargets(edges []*BehaviorEdge) string {
+	targets := make([]string, len(edges))
+	for i, edge := range edges {
+		targets[i] = edge.To
+	}
+	return strings.Join(targets, ",")
+}
@@ -5,6 +5,8 @@ package behaviors
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -301,6 +303,103 @@ func TestBehaviorGraphBuild(t *testing.T) {
 	t.Logf("✓ Graph Construction Test Passed: %d nodes, %d transitions", len(graph.Nodes), len(transitions))
 }
 
+// TestBuildGraph tests one-call graph construction from an adjacency list
+func TestBuildGraph(t *testing.T) {
+	handBuilt := buildTestBehaviorGraph()
+
+	nodes := make([]*BehaviorNode, 0, len(handBuilt.Nodes))
+	for id, node := range handBuilt.Nodes {
+		nodes = append(nodes, &BehaviorNode{ID: id, Name: node.Name, Category: node.Category})
+	}
+	edges := [][2]string{
+		{"idle", "active"},
+		{"active", "busy"},
+		{"busy", "degraded"},
+		{"degraded", "recovery"},
+		{"recovery", "active"},
+		{"active", "idle"},
+		{"busy", "shutdown"},
+		{"idle", "shutdown"},
+	}
+
+	graph, err := BuildGraph(nodes, edges)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	if len(graph.Nodes) != len(handBuilt.Nodes) {
+		t.Errorf("Expected %d nodes, got %d", len(handBuilt.Nodes), len(graph.Nodes))
+	}
+	for id := range handBuilt.Nodes {
+		if len(graph.Edges[id]) != len(handBuilt.Edges[id]) {
+			t.Errorf("Node %s: expected %d edges, got %d", id, len(handBuilt.Edges[id]), len(graph.Edges[id]))
+		}
+	}
+
+	// Bad edge endpoint is reported
+	_, err = BuildGraph(nodes, [][2]string{{"idle", "active"}, {"idle", "missing"}})
+	if err == nil {
+		t.Fatal("Expected error for edge to missing node, got nil")
+	}
+	if !strings.Contains(err.Error(), "idle -> missing") {
+		t.Errorf("Expected error to name the bad edge, got %v", err)
+	}
+}
+
+// TestCheapestPath tests weighted shortest-path search
+func TestCheapestPath(t *testing.T) {
+	graph := NewBehaviorGraph()
+	for _, id := range []string{"start", "middle", "detour_a", "detour_b", "end"} {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+
+	always := func() bool { return true }
+	graph.AddEdge("start", "middle", always, time.Millisecond, true)
+	graph.AddEdge("middle", "end", always, time.Millisecond, true)
+	graph.AddEdge("start", "detour_a", always, time.Millisecond, true)
+	graph.AddEdge("detour_a", "detour_b", always, time.Millisecond, true)
+	graph.AddEdge("detour_b", "end", always, time.Millisecond, true)
+
+	// Make the two-hop route expensive so the three-hop detour wins
+	graph.Edges["start"][0].Weight = 10
+
+	seq, err := graph.CheapestPath("start", "end")
+	if err != nil {
+		t.Fatalf("CheapestPath failed: %v", err)
+	}
+
+	if seq.Cost != 3 {
+		t.Errorf("Expected cheapest cost 3, got %d", seq.Cost)
+	}
+	want := []string{"start", "detour_a", "detour_b", "end"}
+	if strings.Join(seq.Path, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected path %v, got %v", want, seq.Path)
+	}
+
+	if _, err := graph.CheapestPath("end", "start"); err == nil {
+		t.Error("Expected error when no path exists, got nil")
+	}
+
+	// Generated sequences report summed edge weights
+	gen := NewPermutationGenerator(graph)
+	sequences, err := gen.GenerateSequences("start", 2)
+	if err != nil {
+		t.Fatalf("GenerateSequences failed: %v", err)
+	}
+	var direct *BehaviorSequence
+	for _, s := range sequences {
+		if strings.Join(s.Path, ",") == "start,middle,end" {
+			direct = s
+		}
+	}
+	if direct == nil {
+		t.Fatal("Expected start,middle,end among the generated sequences")
+	}
+	if direct.Cost != 11 {
+		t.Errorf("Expected start,middle,end to cost 11, got %d", direct.Cost)
+	}
+}
+
 // TestPermutationGeneration tests sequence generation
 func TestPermutationGeneration(t *testing.T) {
 	t.Log("\nTesting Permutation Generation")
@@ -378,6 +477,884 @@ func buildTestBehaviorGraph() *BehaviorGraph {
 	return graph
 }
 
+// TestExecuteSequenceStopsAtFailingStep tests per-step sequence diagnostics
+func TestExecuteSequenceStopsAtFailingStep(t *testing.T) {
+	var nodes []*BehaviorNode
+	for _, id := range []string{"browse", "cart", "checkout", "confirm"} {
+		nodes = append(nodes, &BehaviorNode{ID: id, Name: id})
+	}
+	graph, err := BuildGraph(
+		nodes,
+		[][2]string{{"browse", "cart"}, {"cart", "checkout"}, {"checkout", "confirm"}},
+	)
+	if err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+
+	var visited []string
+	seq := &BehaviorSequence{Path: []string{"browse", "cart", "checkout", "confirm"}}
+	result, err := graph.ExecuteSequence(context.Background(), seq, func(node string) error {
+		visited = append(visited, node)
+		if node == "checkout" {
+			return fmt.Errorf("payment declined")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteSequence returned error: %v", err)
+	}
+
+	if result.Success {
+		t.Error("Expected sequence to fail")
+	}
+	if len(result.Steps) != 3 {
+		t.Fatalf("Expected 3 recorded steps, got %d", len(result.Steps))
+	}
+	if !result.Steps[0].Success || !result.Steps[1].Success {
+		t.Error("Expected the first two steps to succeed")
+	}
+	if result.FailedStep != 2 || result.Steps[2].NodeID != "checkout" || result.Steps[2].Error == nil {
+		t.Errorf("Expected failure at step 3 (checkout), got step %d: %+v", result.FailedStep+1, result.Steps[2])
+	}
+	if len(visited) != 3 {
+		t.Errorf("Expected execution to stop after checkout, visited %v", visited)
+	}
+}
+
+// TestGraphSnapshotRestore tests undoing a batch of mutations via snapshot
+func TestGraphSnapshotRestore(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	snapshot := graph.Snapshot()
+	before := graphSignature(graph)
+
+	mutGen := NewMutationGenerator(graph, 42)
+	mutations := []*Mutation{
+		{ID: "m1", Type: MutationRemoveNode, TargetNode: "busy", Results: map[string]interface{}{}},
+		{ID: "m2", Type: MutationAddNode, Payload: &BehaviorNode{ID: "ghost", Name: "ghost"}, Results: map[string]interface{}{}},
+		{ID: "m3", Type: MutationModifyLatency, Payload: time.Second, Results: map[string]interface{}{}},
+		{ID: "m4", Type: MutationConstraint, TargetNode: "idle", Payload: "no_network", Results: map[string]interface{}{}},
+	}
+	for _, mut := range mutations {
+		if err := mutGen.ApplyMutation(mut); err != nil {
+			t.Fatalf("Failed to apply mutation %s: %v", mut.ID, err)
+		}
+	}
+	if graphSignature(graph) == before {
+		t.Fatal("Expected mutations to change the graph")
+	}
+
+	graph.Restore(snapshot)
+	if after := graphSignature(graph); after != before {
+		t.Errorf("Expected restored graph to match snapshot\nbefore: %s\nafter:  %s", before, after)
+	}
+
+	// RunBatch restores automatically, even when the experiment fails
+	err := mutGen.RunBatch(mutations, func(bg *BehaviorGraph) error {
+		if _, exists := bg.Nodes["ghost"]; !exists {
+			t.Error("Expected experiment to see the mutated graph")
+		}
+		return fmt.Errorf("experiment failed")
+	})
+	if err == nil {
+		t.Error("Expected RunBatch to return the experiment error")
+	}
+	if after := graphSignature(graph); after != before {
+		t.Errorf("Expected RunBatch to restore the graph\nbefore: %s\nafter:  %s", before, after)
+	}
+}
+
+// graphSignature renders a graph's nodes and edges in a stable order. Nodes
+// and edge sources are sorted, but each source's edges keep their slice
+// order, since that order decides which successor is tried first.
+func graphSignature(bg *BehaviorGraph) string {
+	var parts []string
+
+	ids := make([]string, 0, len(bg.Nodes))
+	for id := range bg.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		node := bg.Nodes[id]
+		parts = append(parts, fmt.Sprintf("node %s %q %q %q %v %v terminal=%t",
+			id, node.Name, node.Description, node.Category, node.Constraints, node.Metadata, bg.terminal[id]))
+	}
+
+	sources := make([]string, 0, len(bg.Edges))
+	for from := range bg.Edges {
+		sources = append(sources, from)
+	}
+	sort.Strings(sources)
+	for _, from := range sources {
+		for _, edge := range bg.Edges[from] {
+			parts = append(parts, fmt.Sprintf("edge[%s] %s->%s %v w%d d%t p%v/%t",
+				from, edge.From, edge.To, edge.Latency, edge.Weight, edge.Deterministic, edge.Probability, edge.Probabilistic))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// TestRevertMutationsRestoresGraph tests that every mutation type can be
+// reverted back to the exact original graph
+func TestRevertMutationsRestoresGraph(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	graph.Nodes["idle"].Constraints = []string{"rate_limit"}
+	graph.Nodes["idle"].Description = "Waiting for work"
+	graph.Nodes["idle"].Metadata = map[string]interface{}{"owner": "scheduler"}
+	graph.MarkTerminal("shutdown")
+	before := graphSignature(graph)
+
+	mutGen := NewMutationGenerator(graph, 42)
+	mutations := []*Mutation{
+		{ID: "m1", Type: MutationRemoveNode, TargetNode: "busy"},
+		{ID: "m2", Type: MutationAddNode, Payload: &BehaviorNode{ID: "ghost", Name: "ghost"}},
+		{ID: "m3", Type: MutationAddEdge},
+		{ID: "m4", Type: MutationRemoveEdge},
+		{ID: "m5", Type: MutationModifyLatency, Payload: time.Second},
+		{ID: "m6", Type: MutationInvertEdge},
+		{ID: "m7", Type: MutationDuplicateNode},
+		{ID: "m8", Type: MutationConstraint, TargetNode: "idle", Payload: "rate_limit"},
+	}
+	for _, mut := range mutations {
+		if err := mutGen.ApplyMutation(mut); err != nil {
+			t.Fatalf("Failed to apply mutation %s: %v", mut.ID, err)
+		}
+	}
+	if graphSignature(graph) == before {
+		t.Fatal("Expected mutations to change the graph")
+	}
+
+	for i := len(mutations) - 1; i >= 0; i-- {
+		if err := mutGen.RevertMutation(mutations[i]); err != nil {
+			t.Fatalf("Failed to revert mutation %s: %v", mutations[i].ID, err)
+		}
+	}
+	if after := graphSignature(graph); after != before {
+		t.Errorf("Expected reverted graph to match original\nbefore: %s\nafter:  %s", before, after)
+	}
+	if deadEnds := graph.DeadEndNodes(); len(deadEnds) != 0 {
+		t.Errorf("Expected shutdown to stay terminal, got dead ends %v", deadEnds)
+	}
+}
+
+// TestModifyLatencyTargetsNodeEdges tests that modify_latency only touches
+// the target node's outgoing edges
+func TestModifyLatencyTargetsNodeEdges(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	original := make(map[*BehaviorEdge]time.Duration)
+	for _, edges := range graph.Edges {
+		for _, edge := range edges {
+			original[edge] = edge.Latency
+		}
+	}
+
+	mutGen := NewMutationGenerator(graph, 42)
+	mutation := &Mutation{ID: "m1", Type: MutationModifyLatency, TargetNode: "active", Payload: time.Second}
+	if err := mutGen.ApplyMutation(mutation); err != nil {
+		t.Fatalf("ApplyMutation failed: %v", err)
+	}
+
+	for from, edges := range graph.Edges {
+		for _, edge := range edges {
+			if from == "active" && edge.Latency != time.Second {
+				t.Errorf("Expected %s->%s latency 1s, got %v", from, edge.To, edge.Latency)
+			}
+			if from != "active" && edge.Latency != original[edge] {
+				t.Errorf("Expected %s->%s latency unchanged, got %v", from, edge.To, edge.Latency)
+			}
+		}
+	}
+
+	previous, ok := mutation.Results["previous_latencies"].(map[*BehaviorEdge]time.Duration)
+	if !ok || len(previous) != len(graph.Edges["active"]) {
+		t.Errorf("Expected previous latencies for active's %d edges, got %v", len(graph.Edges["active"]), previous)
+	}
+
+	single := &Mutation{ID: "m2", Type: MutationModifyLatency, TargetEdge: "busy->shutdown", Payload: time.Minute}
+	if err := mutGen.ApplyMutation(single); err != nil {
+		t.Fatalf("ApplyMutation failed: %v", err)
+	}
+	for _, edge := range graph.Edges["busy"] {
+		if edge.To == "shutdown" && edge.Latency != time.Minute {
+			t.Errorf("Expected busy->shutdown latency 1m, got %v", edge.Latency)
+		}
+		if edge.To != "shutdown" && edge.Latency != original[edge] {
+			t.Errorf("Expected busy->%s latency unchanged, got %v", edge.To, edge.Latency)
+		}
+	}
+}
+
+// TestMutationGeneratorDeterministic tests that a seed fixes the whole
+// mutation sequence, including add_edge endpoints
+func TestMutationGeneratorDeterministic(t *testing.T) {
+	run := func() string {
+		graph := buildTestBehaviorGraph()
+		mutGen := NewMutationGenerator(graph, 7)
+		mutations, err := mutGen.GenerateMutations(40)
+		if err != nil {
+			t.Fatalf("GenerateMutations failed: %v", err)
+		}
+		for _, mut := range mutations {
+			if err := mutGen.ApplyMutation(mut); err != nil {
+				t.Fatalf("Failed to apply mutation %s: %v", mut.ID, err)
+			}
+		}
+		return graphSignature(graph)
+	}
+
+	first := run()
+	if second := run(); second != first {
+		t.Errorf("Expected identical graphs for the same seed\nfirst:  %s\nsecond: %s", first, second)
+	}
+	if first == graphSignature(buildTestBehaviorGraph()) {
+		t.Error("Expected mutations to change the graph")
+	}
+}
+
+// TestLatencyBucketsBimodal tests that a bimodal latency distribution shows
+// up as two populated histogram buckets
+func TestLatencyBucketsBimodal(t *testing.T) {
+	profiler := NewPerformanceProfiler()
+
+	results := make([]*ExecutionResult, 0)
+	for i := 0; i < 5; i++ {
+		results = append(results, &ExecutionResult{Success: true, Duration: 2 * time.Millisecond})
+		results = append(results, &ExecutionResult{Success: true, Duration: 200 * time.Millisecond})
+	}
+
+	metrics := profiler.RecordExecution(results)
+	if len(metrics.Buckets) != len(DefaultLatencyBuckets)+1 {
+		t.Fatalf("Expected %d buckets, got %d", len(DefaultLatencyBuckets)+1, len(metrics.Buckets))
+	}
+
+	for _, bucket := range metrics.Buckets {
+		want := 0
+		switch bucket.UpperBound {
+		case 5 * time.Millisecond, 250 * time.Millisecond:
+			want = 5
+		}
+		if bucket.Count != want {
+			t.Errorf("Bucket (%v, %v]: expected %d, got %d", bucket.LowerBound, bucket.UpperBound, want, bucket.Count)
+		}
+	}
+}
+
+// TestStateMachineSimulateTime tests that simulated time accumulates edge
+// latency without sleeping
+func TestStateMachineSimulateTime(t *testing.T) {
+	graph := NewBehaviorGraph()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+	always := func() bool { return true }
+	graph.AddEdge("a", "b", always, time.Second, true)
+	graph.AddEdge("b", "c", always, time.Second, true)
+	graph.AddEdge("c", "d", always, time.Second, true)
+
+	sm := NewStateMachine(graph, StateMachineConfig{
+		InitialState: "a",
+		MaxSteps:     10,
+		SimulateTime: true,
+	})
+
+	start := time.Now()
+	if err := sm.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Simulated run took %v, expected milliseconds", elapsed)
+	}
+
+	metrics := sm.GetMetrics()
+	if total := metrics["total_latency"].(time.Duration); total != 3*time.Second {
+		t.Errorf("Expected 3s total latency, got %v", total)
+	}
+	if avg := metrics["avg_latency"].(time.Duration); avg != time.Second {
+		t.Errorf("Expected 1s average latency, got %v", avg)
+	}
+	if simulated := metrics["simulated_time"].(time.Duration); simulated != 3*time.Second {
+		t.Errorf("Expected 3s simulated time, got %v", simulated)
+	}
+}
+
+// buildDiamondGraph builds start -> {left, right} -> end -> start, with the
+// right branch weighted higher
+func buildDiamondGraph() *BehaviorGraph {
+	graph := NewBehaviorGraph()
+	for _, id := range []string{"start", "left", "right", "end"} {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+	always := func() bool { return true }
+	graph.AddEdge("start", "left", always, 0, true)
+	graph.AddEdge("start", "right", always, 0, true)
+	graph.AddEdge("left", "end", always, 0, true)
+	graph.AddEdge("right", "end", always, 0, true)
+	graph.AddEdge("end", "start", always, 0, true)
+	graph.Edges["start"][1].Weight = 5
+	return graph
+}
+
+// walkPath runs a state machine and returns the visited states in order
+func walkPath(t *testing.T, sm *StateMachine) string {
+	t.Helper()
+	if err := sm.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	path := []string{sm.config.InitialState}
+	for _, tr := range sm.transitions {
+		path = append(path, tr.To)
+	}
+	return strings.Join(path, ",")
+}
+
+// TestStateMachineSelectionStrategies tests that each strategy walks a
+// diamond-shaped graph differently
+func TestStateMachineSelectionStrategies(t *testing.T) {
+	tests := []struct {
+		strategy SelectionStrategy
+		want     string
+	}{
+		{"", "start,left,end,start,left,end,start"},
+		{SelectFirst, "start,left,end,start,left,end,start"},
+		{SelectHighestWeight, "start,right,end,start,right,end,start"},
+		{SelectRoundRobin, "start,left,end,start,right,end,start"},
+	}
+
+	for _, tt := range tests {
+		sm := NewStateMachine(buildDiamondGraph(), StateMachineConfig{
+			InitialState:      "start",
+			MaxSteps:          6,
+			SelectionStrategy: tt.strategy,
+		})
+		if got := walkPath(t, sm); got != tt.want {
+			t.Errorf("Strategy %q: expected %s, got %s", tt.strategy, tt.want, got)
+		}
+	}
+
+	sm := NewStateMachine(buildDiamondGraph(), StateMachineConfig{
+		InitialState:      "start",
+		MaxSteps:          1,
+		SelectionStrategy: "sideways",
+	})
+	if err := sm.Execute(context.Background()); err == nil {
+		t.Error("Expected an error for an unknown selection strategy")
+	}
+}
+
+// TestStateMachineWeightedRandomReproducible tests that the same seed gives
+// the same walk
+func TestStateMachineWeightedRandomReproducible(t *testing.T) {
+	walk := func(seed int64) string {
+		sm := NewStateMachine(buildDiamondGraph(), StateMachineConfig{
+			InitialState:      "start",
+			MaxSteps:          60,
+			SelectionStrategy: SelectWeightedRandom,
+			Seed:              seed,
+		})
+		return walkPath(t, sm)
+	}
+
+	first := walk(42)
+	if second := walk(42); second != first {
+		t.Errorf("Expected identical walks for the same seed:\n%s\n%s", first, second)
+	}
+}
+
+// TestStateMachineWeightedRandomDistribution tests that visits follow edge
+// weights over many steps
+func TestStateMachineWeightedRandomDistribution(t *testing.T) {
+	sm := NewStateMachine(buildDiamondGraph(), StateMachineConfig{
+		InitialState:      "start",
+		MaxSteps:          6000,
+		SelectionStrategy: SelectWeightedRandom,
+		Seed:              1,
+	})
+	if err := sm.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	left, right := sm.visited["left"], sm.visited["right"]
+	share := float64(right) / float64(left+right)
+	// right has weight 5 against left's 1
+	if want := 5.0 / 6.0; share < want-0.05 || share > want+0.05 {
+		t.Errorf("Expected right share near %.2f, got %.2f (%d vs %d)", want, share, right, left)
+	}
+}
+
+// TestGenerateSequencesBranchPaths tests that sibling branches from the same
+// node keep their own paths and never write into the caller's backing array
+func TestGenerateSequencesBranchPaths(t *testing.T) {
+	graph := NewBehaviorGraph()
+	for _, id := range []string{"root", "a", "b", "c", "d"} {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+	always := func() bool { return true }
+	graph.AddEdge("root", "a", always, 0, true)
+	graph.AddEdge("a", "b", always, 0, true)
+	graph.AddEdge("b", "c", always, 0, true)
+	graph.AddEdge("b", "d", always, 0, true)
+
+	sequences, err := NewPermutationGenerator(graph).GenerateSequences("root", 3)
+	if err != nil {
+		t.Fatalf("GenerateSequences failed: %v", err)
+	}
+	if len(sequences) != 2 {
+		t.Fatalf("Expected 2 sequences, got %d", len(sequences))
+	}
+
+	got := []string{
+		strings.Join(sequences[0].Path, ","),
+		strings.Join(sequences[1].Path, ","),
+	}
+	want := []string{"root,a,b,c", "root,a,b,d"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sequence %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+
+	// A path with spare capacity must be copied, not appended into
+	backing := []string{"root", "untouched"}
+	var branched []*BehaviorSequence
+	NewPermutationGenerator(graph).generateSequencesRecursive("root", backing[:1], 0, 3, &branched, nil)
+	if backing[1] != "untouched" {
+		t.Errorf("Expected the caller's backing array to be left alone, got %v", backing)
+	}
+}
+
+// TestGenerateSequencesCacheByDepth tests that a shallow call does not poison
+// the cache for a deeper one
+func TestGenerateSequencesCacheByDepth(t *testing.T) {
+	graph := NewBehaviorGraph()
+	ids := []string{"a", "b", "c", "d", "e"}
+	for _, id := range ids {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+	for i := 0; i < len(ids)-1; i++ {
+		graph.AddEdge(ids[i], ids[i+1], func() bool { return true }, 0, true)
+	}
+
+	pg := NewPermutationGenerator(graph)
+	shallow, err := pg.GenerateSequences("a", 2)
+	if err != nil {
+		t.Fatalf("GenerateSequences failed: %v", err)
+	}
+	deep, err := pg.GenerateSequences("a", 4)
+	if err != nil {
+		t.Fatalf("GenerateSequences failed: %v", err)
+	}
+
+	if len(shallow) != 1 || len(shallow[0].Path) != 3 {
+		t.Fatalf("Expected one 3-node path at depth 2, got %v", shallow)
+	}
+	if len(deep) != 1 || len(deep[0].Path) != 5 {
+		t.Fatalf("Expected one 5-node path at depth 4, got %v", deep)
+	}
+}
+
+// TestGenerateAcyclicSequences tests that acyclic generation stops at cycles
+// and never repeats a node within a path
+func TestGenerateAcyclicSequences(t *testing.T) {
+	pg := NewPermutationGenerator(buildTestBehaviorGraph())
+
+	sequences, err := pg.GenerateAcyclicSequences("idle", 20)
+	if err != nil {
+		t.Fatalf("GenerateAcyclicSequences failed: %v", err)
+	}
+
+	got := make([]string, 0, len(sequences))
+	for _, seq := range sequences {
+		seen := make(map[string]bool)
+		for _, node := range seq.Path {
+			if seen[node] {
+				t.Errorf("Path %v repeats %s", seq.Path, node)
+			}
+			seen[node] = true
+		}
+		got = append(got, strings.Join(seq.Path, ","))
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"idle,active,busy,degraded,recovery",
+		"idle,active,busy,shutdown",
+		"idle,shutdown",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected simple paths %v, got %v", want, got)
+	}
+
+	cyclic, err := pg.GenerateSequences("idle", 8)
+	if err != nil {
+		t.Fatalf("GenerateSequences failed: %v", err)
+	}
+	if len(cyclic) <= len(sequences) {
+		t.Errorf("Expected cyclic generation to unroll loops into more paths, got %d", len(cyclic))
+	}
+}
+
+// TestPerformancePercentilesSmallSamples tests that percentiles stay in range
+// and ordered for small and large samples
+func TestPerformancePercentilesSmallSamples(t *testing.T) {
+	for _, n := range []int{1, 2, 100} {
+		results := make([]*ExecutionResult, n)
+		for i := range results {
+			results[i] = &ExecutionResult{Success: true, Duration: time.Duration(i+1) * time.Millisecond}
+		}
+
+		metrics := NewPerformanceProfiler().RecordExecution(results)
+		if metrics.P95Latency > metrics.P99Latency || metrics.P99Latency > metrics.MaxLatency {
+			t.Errorf("n=%d: expected P95 <= P99 <= Max, got %v, %v, %v",
+				n, metrics.P95Latency, metrics.P99Latency, metrics.MaxLatency)
+		}
+		if metrics.P95Latency < metrics.MinLatency {
+			t.Errorf("n=%d: P95 %v below Min %v", n, metrics.P95Latency, metrics.MinLatency)
+		}
+	}
+
+	results := make([]*ExecutionResult, 100)
+	for i := range results {
+		results[i] = &ExecutionResult{Success: true, Duration: time.Duration(i+1) * time.Millisecond}
+	}
+	metrics := NewPerformanceProfiler().RecordExecution(results)
+	if metrics.P95Latency != 95*time.Millisecond || metrics.P99Latency != 99*time.Millisecond {
+		t.Errorf("Expected nearest-rank P95=95ms and P99=99ms, got %v and %v", metrics.P95Latency, metrics.P99Latency)
+	}
+}
+
+// TestPerformanceHistogramAndStdDev tests custom buckets and the latency
+// standard deviation
+func TestPerformanceHistogramAndStdDev(t *testing.T) {
+	profiler := NewPerformanceProfiler().WithBuckets([]time.Duration{
+		time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond,
+	})
+
+	durations := []time.Duration{
+		500 * time.Microsecond,
+		2 * time.Millisecond, 4 * time.Millisecond,
+		20 * time.Millisecond, 40 * time.Millisecond, 60 * time.Millisecond,
+		200 * time.Millisecond,
+	}
+	results := make([]*ExecutionResult, len(durations))
+	for i, d := range durations {
+		results[i] = &ExecutionResult{Success: true, Duration: d}
+	}
+
+	metrics := profiler.RecordExecution(results)
+
+	want := map[string]int{"<=1ms": 1, "1ms-10ms": 2, "10ms-100ms": 3, ">100ms": 1}
+	if len(metrics.Histogram) != len(want) {
+		t.Errorf("Expected %d histogram buckets, got %v", len(want), metrics.Histogram)
+	}
+	for label, count := range want {
+		if metrics.Histogram[label] != count {
+			t.Errorf("Bucket %s: expected %d, got %d", label, count, metrics.Histogram[label])
+		}
+	}
+
+	// Mean is 46.642857ms; population std dev is about 65.89ms
+	wantStdDev := 65.89 * float64(time.Millisecond)
+	if diff := float64(metrics.StdDevLatency) - wantStdDev; diff > 0.1*float64(time.Millisecond) || diff < -0.1*float64(time.Millisecond) {
+		t.Errorf("Expected std dev near 65.89ms, got %v", metrics.StdDevLatency)
+	}
+}
+
+// TestPerformanceRuntimeStats tests that memory and goroutine figures are
+// captured only when enabled
+func TestPerformanceRuntimeStats(t *testing.T) {
+	results := []*ExecutionResult{{Success: true, Duration: time.Millisecond}}
+
+	enabled := NewPerformanceProfiler().RecordExecution(results)
+	if enabled.MemoryUsage == 0 || enabled.GoroutineCount == 0 {
+		t.Errorf("Expected runtime stats, got memory %d, goroutines %d", enabled.MemoryUsage, enabled.GoroutineCount)
+	}
+
+	profiler := NewPerformanceProfiler()
+	profiler.CaptureRuntimeStats = false
+	disabled := profiler.RecordExecution(results)
+	if disabled.MemoryUsage != 0 || disabled.GoroutineCount != 0 {
+		t.Errorf("Expected no runtime stats, got memory %d, goroutines %d", disabled.MemoryUsage, disabled.GoroutineCount)
+	}
+}
+
+// TestEdgeCoveragePercent tests distinct edge coverage over the sample graph
+func TestEdgeCoveragePercent(t *testing.T) {
+	analyzer := NewCoverageAnalyzer(buildTestBehaviorGraph())
+
+	// 4 of the sample graph's 8 edges, one of them twice
+	analyzer.RecordTransition("idle", "active")
+	analyzer.RecordTransition("active", "busy")
+	analyzer.RecordTransition("busy", "degraded")
+	analyzer.RecordTransition("degraded", "recovery")
+	analyzer.RecordTransition("idle", "active")
+
+	report := analyzer.GenerateReport()
+	if report.TotalEdges != 8 {
+		t.Errorf("Expected 8 edges, got %d", report.TotalEdges)
+	}
+	if math.Abs(report.EdgeCoveragePercent-50) > 0.01 {
+		t.Errorf("Expected 50%% edge coverage, got %.2f", report.EdgeCoveragePercent)
+	}
+
+	want := []string{"active->idle", "busy->shutdown", "idle->shutdown", "recovery->active"}
+	if strings.Join(report.UncoveredEdges, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected uncovered edges %v, got %v", want, report.UncoveredEdges)
+	}
+}
+
+// TestPathCoverage tests coverage of registered target paths
+func TestPathCoverage(t *testing.T) {
+	analyzer := NewCoverageAnalyzer(buildTestBehaviorGraph())
+	analyzer.SetTargetPaths([][]string{
+		{"idle", "active", "busy"},
+		{"idle", "shutdown"},
+		{"idle", "active", "idle"},
+	})
+
+	analyzer.RecordPath([]string{"idle", "active", "busy"})
+	analyzer.RecordPath([]string{"idle", "shutdown"})
+	analyzer.RecordPath([]string{"idle", "shutdown"})
+	analyzer.RecordPath([]string{"busy", "shutdown"}) // not a target
+
+	report := analyzer.GenerateReport()
+	if report.TotalPaths != 3 {
+		t.Errorf("Expected 3 target paths, got %d", report.TotalPaths)
+	}
+	if math.Abs(report.PathCoveragePercent-66.67) > 0.01 {
+		t.Errorf("Expected 66.7%% path coverage, got %.2f", report.PathCoveragePercent)
+	}
+	if len(report.MissingPaths) != 1 || report.MissingPaths[0] != "idle->active->idle" {
+		t.Errorf("Expected idle->active->idle to be missing, got %v", report.MissingPaths)
+	}
+}
+
+// TestShortestPathAndReachability tests BFS routes and reachability on the
+// sample graph
+func TestShortestPathAndReachability(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	graph.AddNode(&BehaviorNode{ID: "orphan", Name: "Disconnected"})
+
+	path, err := graph.ShortestPath("idle", "shutdown")
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	if strings.Join(path, ",") != "idle,shutdown" {
+		t.Errorf("Expected idle,shutdown, got %v", path)
+	}
+
+	path, err = graph.ShortestPath("recovery", "shutdown")
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	if strings.Join(path, ",") != "recovery,active,busy,shutdown" {
+		t.Errorf("Expected recovery,active,busy,shutdown, got %v", path)
+	}
+
+	path, err = graph.ShortestPath("idle", "orphan")
+	if err != nil || len(path) != 0 {
+		t.Errorf("Expected an empty path and no error for an unreachable node, got %v (err %v)", path, err)
+	}
+	if _, err := graph.ShortestPath("idle", "missing"); err == nil {
+		t.Error("Expected an error for a missing node")
+	}
+
+	reached := graph.Reachable("idle")
+	if len(reached) != 6 || reached["orphan"] {
+		t.Errorf("Expected the 6 sample states to be reachable from idle and not orphan, got %v", reached)
+	}
+	if reached := graph.Reachable("shutdown"); len(reached) != 1 {
+		t.Errorf("Expected only shutdown itself to be reachable from shutdown, got %v", reached)
+	}
+}
+
+// TestStronglyConnectedComponents tests cycle detection with Tarjan's
+// algorithm
+func TestStronglyConnectedComponents(t *testing.T) {
+	nodes := make([]*BehaviorNode, 0)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		nodes = append(nodes, &BehaviorNode{ID: id, Name: id})
+	}
+	graph, err := BuildGraph(nodes, [][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"}, // cycle
+		{"c", "d"}, // d hangs off the cycle; e stands alone
+	})
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+
+	components := graph.StronglyConnectedComponents()
+	if len(components) != 3 {
+		t.Fatalf("Expected 3 components, got %v", components)
+	}
+
+	multi := 0
+	for _, component := range components {
+		if len(component) > 1 {
+			multi++
+			if strings.Join(component, ",") != "a,b,c" {
+				t.Errorf("Expected cycle a,b,c, got %v", component)
+			}
+		}
+	}
+	if multi != 1 {
+		t.Errorf("Expected exactly one multi-node component, got %d", multi)
+	}
+	if strings.Join(components[1], ",") != "d" || strings.Join(components[2], ",") != "e" {
+		t.Errorf("Expected singletons d and e, got %v", components[1:])
+	}
+}
+
+// TestDeadEndAndUnreachableNodes tests graph linting with terminal nodes
+func TestDeadEndAndUnreachableNodes(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+
+	deadEnds := graph.DeadEndNodes()
+	if strings.Join(deadEnds, ",") != "shutdown" {
+		t.Errorf("Expected shutdown as the only dead end, got %v", deadEnds)
+	}
+
+	graph.MarkTerminal("shutdown")
+	if deadEnds := graph.DeadEndNodes(); len(deadEnds) != 0 {
+		t.Errorf("Expected no dead ends after marking shutdown terminal, got %v", deadEnds)
+	}
+	for _, err := range graph.Validate() {
+		if strings.Contains(err.Error(), "shutdown has no outgoing edges") {
+			t.Errorf("Validate should not flag terminal node: %v", err)
+		}
+	}
+
+	if unreachable := graph.UnreachableNodes("idle"); len(unreachable) != 0 {
+		t.Errorf("Expected every node reachable from idle, got %v", unreachable)
+	}
+	unreachable := graph.UnreachableNodes("shutdown")
+	if strings.Join(unreachable, ",") != "active,busy,degraded,idle,recovery" {
+		t.Errorf("Expected every other node unreachable from shutdown, got %v", unreachable)
+	}
+}
+
+// TestTerminalMarksSurviveCopies tests that terminal marks survive clone, Snapshot/Restore and RunBatch
+func TestTerminalMarksSurviveCopies(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	graph.MarkTerminal("shutdown")
+
+	clone := graph.clone()
+	if deadEnds := clone.DeadEndNodes(); len(deadEnds) != 0 {
+		t.Errorf("Expected clone to keep shutdown terminal, got dead ends %v", deadEnds)
+	}
+	clone.MarkTerminal("idle")
+	if graph.terminal["idle"] {
+		t.Error("Marking the clone terminal should not affect the original")
+	}
+
+	snapshot := graph.Snapshot()
+	if err := graph.RemoveNode("shutdown"); err != nil {
+		t.Fatalf("RemoveNode failed: %v", err)
+	}
+	graph.Restore(snapshot)
+	if deadEnds := graph.DeadEndNodes(); len(deadEnds) != 0 {
+		t.Errorf("Expected Restore to keep shutdown terminal, got dead ends %v", deadEnds)
+	}
+
+	mutGen := NewMutationGenerator(graph, 42)
+	mutations := []*Mutation{{ID: "m1", Type: MutationRemoveNode, TargetNode: "shutdown"}}
+	if err := mutGen.RunBatch(mutations, nil); err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+	if deadEnds := graph.DeadEndNodes(); len(deadEnds) != 0 {
+		t.Errorf("Expected RunBatch to keep shutdown terminal, got dead ends %v", deadEnds)
+	}
+}
+
+// TestRemoveNodePrunesInboundEdges tests node and edge removal
+func TestRemoveNodePrunesInboundEdges(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+
+	mutGen := NewMutationGenerator(graph, 42)
+	mutation := &Mutation{ID: "m1", Type: MutationRemoveNode, TargetNode: "busy", Results: map[string]interface{}{}}
+	if err := mutGen.ApplyMutation(mutation); err != nil {
+		t.Fatalf("ApplyMutation failed: %v", err)
+	}
+
+	if _, exists := graph.Nodes["busy"]; exists {
+		t.Fatal("Expected busy to be removed")
+	}
+	for from, edges := range graph.Edges {
+		if from == "busy" {
+			t.Errorf("Expected outgoing edges of busy to be removed")
+		}
+		for _, edge := range edges {
+			if edge.To == "busy" {
+				t.Errorf("Edge %s->%s still references removed node", from, edge.To)
+			}
+		}
+	}
+	if err := graph.RemoveNode("busy"); err == nil {
+		t.Error("Expected error removing a missing node")
+	}
+
+	if err := graph.RemoveEdge("idle", "shutdown"); err != nil {
+		t.Fatalf("RemoveEdge failed: %v", err)
+	}
+	for _, edge := range graph.Edges["idle"] {
+		if edge.To == "shutdown" {
+			t.Error("Expected idle->shutdown to be removed")
+		}
+	}
+	if err := graph.RemoveEdge("idle", "shutdown"); err == nil {
+		t.Error("Expected error removing a missing edge")
+	}
+}
+
+// TestProbabilisticEdges tests that probabilistic edges fire at roughly
+// their probability with a fixed seed
+func TestProbabilisticEdges(t *testing.T) {
+	graph := NewBehaviorGraph()
+	for _, id := range []string{"idle", "lucky", "fallback", "never"} {
+		graph.AddNode(&BehaviorNode{ID: id, Name: id})
+	}
+	if err := graph.AddProbabilisticEdge("idle", "never", 0, 0); err != nil {
+		t.Fatalf("AddProbabilisticEdge failed: %v", err)
+	}
+	if err := graph.AddProbabilisticEdge("idle", "lucky", 0.5, 0); err != nil {
+		t.Fatalf("AddProbabilisticEdge failed: %v", err)
+	}
+	graph.AddEdge("idle", "fallback", nil, 0, true)
+	graph.AddEdge("lucky", "idle", nil, 0, true)
+	graph.AddEdge("fallback", "idle", nil, 0, true)
+	graph.AddEdge("never", "idle", nil, 0, true)
+
+	if err := graph.AddProbabilisticEdge("idle", "lucky", 1.5, 0); err == nil {
+		t.Error("Expected error for probability above 1")
+	}
+
+	run := func() map[string]int {
+		sm := NewStateMachine(graph, StateMachineConfig{
+			InitialState: "idle",
+			MaxSteps:     4000,
+			Seed:         99,
+			SimulateTime: true,
+		})
+		if err := sm.Execute(context.Background()); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return sm.GetMetrics()["visited_states"].(map[string]int)
+	}
+
+	visited := run()
+	if visited["never"] != 0 {
+		t.Errorf("Expected probability 0 edge never taken, taken %d times", visited["never"])
+	}
+	// fallback is a plain edge, so its unset Probability never blocks it
+	ratio := float64(visited["lucky"]) / float64(visited["lucky"]+visited["fallback"])
+	if ratio < 0.45 || ratio > 0.55 {
+		t.Errorf("Expected probability 0.5 edge taken about half the time, got %.3f", ratio)
+	}
+	if again := run(); again["lucky"] != visited["lucky"] {
+		t.Errorf("Expected the same seed to reproduce the walk, got %d and %d", visited["lucky"], again["lucky"])
+	}
+}
+
 // TestCoverageAnalysis tests coverage tracking
 func TestCoverageAnalysis(t *testing.T) {
 	t.Log("\nTesting Coverage Analysis")
@@ -412,6 +1389,101 @@ func TestCoverageAnalysis(t *testing.T) {
 		report.VisitedNodes, report.TotalNodes, report.CoveragePercent)
 }
 
+// TestWeightedCoverage tests that important uncovered nodes lower weighted coverage
+func TestWeightedCoverage(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	graph.Nodes["busy"].Metadata = map[string]interface{}{"coverage_weight": 10.0}
+
+	analyzer := NewCoverageAnalyzer(graph)
+	for _, id := range []string{"idle", "active", "degraded", "recovery", "shutdown"} {
+		analyzer.RecordVisit(id)
+	}
+
+	report := analyzer.GenerateReport()
+	if report.WeightedCoveragePercent >= report.CoveragePercent {
+		t.Errorf("Expected weighted coverage below raw coverage with heavy node uncovered, got %.1f%% vs %.1f%%",
+			report.WeightedCoveragePercent, report.CoveragePercent)
+	}
+	// 5 of 15 weight units visited
+	if want := 5.0 / 15.0 * 100; report.WeightedCoveragePercent < want-0.01 || report.WeightedCoveragePercent > want+0.01 {
+		t.Errorf("Expected weighted coverage %.1f%%, got %.1f%%", want, report.WeightedCoveragePercent)
+	}
+
+	analyzer.RecordVisit("busy")
+	if report := analyzer.GenerateReport(); report.WeightedCoveragePercent != 100 {
+		t.Errorf("Expected full weighted coverage, got %.1f%%", report.WeightedCoveragePercent)
+	}
+}
+
+// TestMergeCoverageReports tests accumulating coverage across runs
+func TestMergeCoverageReports(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+
+	first := NewCoverageAnalyzer(graph)
+	first.RecordVisit("idle")
+	first.RecordVisit("active")
+	first.RecordTransition("idle", "active")
+
+	second := NewCoverageAnalyzer(graph)
+	second.RecordVisit("active")
+	second.RecordVisit("busy")
+	second.RecordTransition("idle", "active")
+	second.RecordTransition("active", "busy")
+
+	r1, r2 := first.GenerateReport(), second.GenerateReport()
+	merged, err := MergeCoverageReports(r1, r2)
+	if err != nil {
+		t.Fatalf("Failed to merge reports: %v", err)
+	}
+
+	if merged.VisitedNodes != 3 {
+		t.Errorf("Expected 3 visited nodes after merge, got %d", merged.VisitedNodes)
+	}
+	if merged.VisitedNodes <= r1.VisitedNodes || merged.VisitedNodes <= r2.VisitedNodes {
+		t.Errorf("Expected merged coverage to exceed each run (%d, %d), got %d",
+			r1.VisitedNodes, r2.VisitedNodes, merged.VisitedNodes)
+	}
+	if merged.EdgeCoverage["idle->active"] != 2 {
+		t.Errorf("Expected idle->active hit twice, got %d", merged.EdgeCoverage["idle->active"])
+	}
+
+	// Reports from a different graph are rejected
+	other := NewBehaviorGraph()
+	other.AddNode(&BehaviorNode{ID: "solo", Name: "solo"})
+	if _, err := MergeCoverageReports(r1, NewCoverageAnalyzer(other).GenerateReport()); err == nil {
+		t.Error("Expected error merging reports from different graphs, got nil")
+	}
+}
+
+// TestCoverageHeatmap tests the multi-graph coverage heatmap
+func TestCoverageHeatmap(t *testing.T) {
+	named := map[string]*CoverageReport{
+		"checkout": {CoveragePercent: 90},
+		"browse":   {CoveragePercent: 50},
+	}
+
+	heatmap := CoverageHeatmap(named)
+	if heatmap != CoverageHeatmap(named) {
+		t.Error("Expected heatmap output to be deterministic")
+	}
+
+	lines := strings.Split(strings.TrimSpace(heatmap), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines:\n%s", len(lines), heatmap)
+	}
+
+	browse, checkout := lines[1], lines[2]
+	if !strings.HasPrefix(browse, "browse") || !strings.Contains(browse, "50.0%") {
+		t.Errorf("Expected browse row at 50.0%%, got %q", browse)
+	}
+	if !strings.HasPrefix(checkout, "checkout") || !strings.Contains(checkout, "90.0%") {
+		t.Errorf("Expected checkout row at 90.0%%, got %q", checkout)
+	}
+	if strings.Count(browse, "+") != 10 || strings.Count(checkout, "#") != 18 {
+		t.Errorf("Expected bars of 10 and 18 cells, got:\n%s", heatmap)
+	}
+}
+
 // TestMutationGeneration tests behavior mutations
 func TestMutationGeneration(t *testing.T) {
 	t.Log("\nTesting Mutation Generation")
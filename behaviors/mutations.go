@@ -5,6 +5,8 @@ package behaviors
 import (
 	"fmt"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -42,6 +44,7 @@ type MutationGenerator struct {
 	mutations     []*Mutation
 	mutationIndex int
 	seed          int64
+	rng           *rand.Rand // seeded once so a seed fixes every random choice
 }
 
 // NewMutationGenerator creates a new mutation generator
@@ -50,6 +53,7 @@ func NewMutationGenerator(bg *BehaviorGraph, seed int64) *MutationGenerator {
 		graph:     bg,
 		mutations: make([]*Mutation, 0),
 		seed:      seed,
+		rng:       rand.New(rand.NewSource(seed)),
 	}
 }
 
@@ -58,20 +62,15 @@ func (mg *MutationGenerator) GenerateMutations(count int) ([]*Mutation, error) {
 	mg.mu.Lock()
 	defer mg.mu.Unlock()
 
-	rng := rand.New(rand.NewSource(mg.seed))
 	mutations := make([]*Mutation, 0, count)
-
-	nodeIDs := make([]string, 0)
-	for nodeID := range mg.graph.Nodes {
-		nodeIDs = append(nodeIDs, nodeID)
-	}
+	nodeIDs := mg.sortedNodeIDs()
 
 	if len(nodeIDs) == 0 {
 		return mutations, fmt.Errorf("graph has no nodes")
 	}
 
 	for i := 0; i < count; i++ {
-		mutationType := mg.generateRandomMutation(rng, nodeIDs)
+		mutationType := mg.generateRandomMutation(mg.rng, nodeIDs)
 		mutations = append(mutations, mutationType)
 	}
 
@@ -140,6 +139,10 @@ func (mg *MutationGenerator) ApplyMutation(mutation *Mutation) error {
 	mg.mu.Lock()
 	defer mg.mu.Unlock()
 
+	if mutation.Results == nil {
+		mutation.Results = make(map[string]interface{})
+	}
+
 	switch mutation.Type {
 	case MutationAddNode:
 		node, ok := mutation.Payload.(*BehaviorNode)
@@ -151,10 +154,12 @@ func (mg *MutationGenerator) ApplyMutation(mutation *Mutation) error {
 		mutation.Results["added_node"] = node.ID
 
 	case MutationRemoveNode:
-		if node, exists := mg.graph.Nodes[mutation.TargetNode]; exists {
-			delete(mg.graph.Nodes, mutation.TargetNode)
-			delete(mg.graph.Edges, mutation.TargetNode)
-			mutation.Results["removed_node"] = node.ID
+		mg.graph.mu.Lock()
+		removed, err := mg.graph.removeNodeLocked(mutation.TargetNode)
+		mg.graph.mu.Unlock()
+		if err == nil {
+			mutation.Results["removed_node"] = mutation.TargetNode
+			mutation.Results["removed_node_state"] = removed
 		}
 
 	case MutationAddEdge:
@@ -168,24 +173,45 @@ func (mg *MutationGenerator) ApplyMutation(mutation *Mutation) error {
 				Latency:       time.Millisecond * 10,
 				Deterministic: true,
 			}
+			mg.graph.mu.Lock()
 			mg.graph.Edges[from] = append(mg.graph.Edges[from], edge)
+			mg.graph.mu.Unlock()
 			mutation.Results["added_edge"] = fmt.Sprintf("%s->%s", from, to)
+			mutation.Results["added_edge_ref"] = edge
 		}
 
 	case MutationModifyLatency:
 		if latency, ok := mutation.Payload.(time.Duration); ok {
-			for _, edges := range mg.graph.Edges {
-				for _, edge := range edges {
-					edge.Latency = latency
+			// Only the target node's outgoing edges change, narrowed to a
+			// single "from->to" edge when TargetEdge is set
+			from, to := mutation.TargetNode, ""
+			if mutation.TargetEdge != "" {
+				parts := strings.SplitN(mutation.TargetEdge, "->", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid target edge %q, want from->to", mutation.TargetEdge)
 				}
+				from, to = parts[0], parts[1]
 			}
+
+			previous := make(map[*BehaviorEdge]time.Duration)
+			mg.graph.mu.Lock()
+			for _, edge := range mg.graph.Edges[from] {
+				if to != "" && edge.To != to {
+					continue
+				}
+				previous[edge] = edge.Latency
+				edge.Latency = latency
+			}
+			mg.graph.mu.Unlock()
 			mutation.Results["modified_latencies"] = latency.String()
+			mutation.Results["previous_latencies"] = previous
 		}
 
 	case MutationConstraint:
 		if constraint, ok := mutation.Payload.(string); ok {
 			if node, exists := mg.graph.Nodes[mutation.TargetNode]; exists {
-				node.Constraints = append(node.Constraints, constraint)
+				mutation.Results["previous_constraints"] = node.Constraints
+				node.Constraints = append(node.Constraints[:len(node.Constraints):len(node.Constraints)], constraint)
 				mutation.Results["added_constraint"] = constraint
 			}
 		}
@@ -195,6 +221,31 @@ func (mg *MutationGenerator) ApplyMutation(mutation *Mutation) error {
 	return nil
 }
 
+// RunBatch snapshots the graph, applies mutations in order, runs experiment
+// against the mutated graph and then restores the snapshot, whatever
+// mutation types ran. The graph is restored even if a mutation or the
+// experiment fails.
+func (mg *MutationGenerator) RunBatch(mutations []*Mutation, experiment func(bg *BehaviorGraph) error) error {
+	snapshot := mg.graph.Snapshot()
+	defer func() {
+		mg.graph.Restore(snapshot)
+		for _, mutation := range mutations {
+			mutation.Applied = false
+		}
+	}()
+
+	for _, mutation := range mutations {
+		if err := mg.ApplyMutation(mutation); err != nil {
+			return fmt.Errorf("mutation %s: %w", mutation.ID, err)
+		}
+	}
+
+	if experiment == nil {
+		return nil
+	}
+	return experiment(mg.graph)
+}
+
 // GetMutationStats returns statistics about mutations
 func (mg *MutationGenerator) GetMutationStats() map[string]interface{} {
 	mg.mu.RLock()
@@ -220,7 +271,9 @@ func (mg *MutationGenerator) GetMutationStats() map[string]interface{} {
 	return stats
 }
 
-// RevertMutation reverts a previously applied mutation
+// RevertMutation reverts a previously applied mutation using the state
+// ApplyMutation recorded in its Results. Mutations sharing a graph should be
+// reverted in the reverse order they were applied.
 func (mg *MutationGenerator) RevertMutation(mutation *Mutation) error {
 	mg.mu.Lock()
 	defer mg.mu.Unlock()
@@ -232,24 +285,40 @@ func (mg *MutationGenerator) RevertMutation(mutation *Mutation) error {
 	switch mutation.Type {
 	case MutationAddNode:
 		if node, ok := mutation.Payload.(*BehaviorNode); ok {
-			delete(mg.graph.Nodes, node.ID)
-			delete(mg.graph.Edges, node.ID)
+			mg.graph.RemoveNode(node.ID)
 		}
 
 	case MutationRemoveNode:
-		// Would need to store original node to restore
-		return fmt.Errorf("cannot revert remove_node without original data")
+		if removed, ok := mutation.Results["removed_node_state"].(*removedNode); ok {
+			mg.graph.mu.Lock()
+			if _, exists := mg.graph.Nodes[removed.node.ID]; exists {
+				mg.graph.mu.Unlock()
+				return fmt.Errorf("cannot revert %s: node %s was re-added", mutation.ID, removed.node.ID)
+			}
+			mg.graph.restoreNodeLocked(removed)
+			mg.graph.mu.Unlock()
+		}
+
+	case MutationAddEdge:
+		if edge, ok := mutation.Results["added_edge_ref"].(*BehaviorEdge); ok {
+			mg.graph.mu.Lock()
+			mg.graph.removeEdgeLocked(edge)
+			mg.graph.mu.Unlock()
+		}
+
+	case MutationModifyLatency:
+		if previous, ok := mutation.Results["previous_latencies"].(map[*BehaviorEdge]time.Duration); ok {
+			mg.graph.mu.Lock()
+			for edge, latency := range previous {
+				edge.Latency = latency
+			}
+			mg.graph.mu.Unlock()
+		}
 
 	case MutationConstraint:
-		if constraint, ok := mutation.Payload.(string); ok {
+		if previous, ok := mutation.Results["previous_constraints"].([]string); ok {
 			if node, exists := mg.graph.Nodes[mutation.TargetNode]; exists {
-				newConstraints := make([]string, 0)
-				for _, c := range node.Constraints {
-					if c != constraint {
-						newConstraints = append(newConstraints, c)
-					}
-				}
-				node.Constraints = newConstraints
+				node.Constraints = previous
 			}
 		}
 	}
@@ -260,21 +329,30 @@ func (mg *MutationGenerator) RevertMutation(mutation *Mutation) error {
 
 // getRandomNodePair returns two different random node IDs
 func (mg *MutationGenerator) getRandomNodePair() []string {
-	nodeIDs := make([]string, 0)
-	for id := range mg.graph.Nodes {
-		nodeIDs = append(nodeIDs, id)
-	}
-
+	nodeIDs := mg.sortedNodeIDs()
 	if len(nodeIDs) < 2 {
 		return nodeIDs
 	}
 
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	i := rng.Intn(len(nodeIDs))
-	j := rng.Intn(len(nodeIDs))
+	i := mg.rng.Intn(len(nodeIDs))
+	j := mg.rng.Intn(len(nodeIDs))
 	for j == i {
-		j = rng.Intn(len(nodeIDs))
+		j = mg.rng.Intn(len(nodeIDs))
 	}
 
 	return []string{nodeIDs[i], nodeIDs[j]}
 }
+
+// sortedNodeIDs returns the graph's node IDs in a stable order, so random
+// picks depend only on the seed and not on map iteration order
+func (mg *MutationGenerator) sortedNodeIDs() []string {
+	mg.graph.mu.RLock()
+	defer mg.graph.mu.RUnlock()
+
+	nodeIDs := make([]string, 0, len(mg.graph.Nodes))
+	for id := range mg.graph.Nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Strings(nodeIDs)
+	return nodeIDs
+}
@@ -5,6 +5,8 @@ package behaviors
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 )
@@ -38,6 +40,8 @@ type OrchestratorConfig struct {
 	ValidateAll     bool
 	MaxSequenceDepth int
 	MutationCount   int
+	Seed            int64 // Seeds the mutation generator; 0 uses the current time
+	StrictValidation bool // Abort when the graph has structural problems instead of warning
 }
 
 // BehaviorOrchestrator coordinates all 10 agents for comprehensive simulation
@@ -50,6 +54,17 @@ type BehaviorOrchestrator struct {
 	startTime       time.Time
 	totalDuration   time.Duration
 	stageMetrics    map[string]time.Duration
+	subscribers     []*agentSubscriber
+}
+
+// AgentStatusUpdate is emitted to subscribers whenever an agent's phase or
+// progress changes
+type AgentStatusUpdate struct {
+	AgentID   string
+	Name      string
+	Phase     AgentPhase
+	Progress  float64
+	Timestamp time.Time
 }
 
 // NewBehaviorOrchestrator creates a new orchestrator
@@ -82,60 +97,81 @@ func (bo *BehaviorOrchestrator) ExecuteAll(ctx context.Context) error {
 	bo.mu.Lock()
 	bo.startTime = time.Now()
 	bo.mu.Unlock()
+	defer bo.closeSubscribers()
+
+	// Phase 0: Structural graph validation
+	validationErrors := bo.graph.Validate()
+	bo.mu.Lock()
+	bo.results["graph_validation_errors"] = validationErrors
+	bo.mu.Unlock()
+	if bo.config.StrictValidation && len(validationErrors) > 0 {
+		return fmt.Errorf("graph validation failed with %d problem(s), first: %w",
+			len(validationErrors), validationErrors[0])
+	}
 
 	// Phase 1: Setup and graph definition (Agent 1)
 	if err := bo.executeAgent1(ctx); err != nil {
 		return fmt.Errorf("agent 1 failed: %w", err)
 	}
 
-	// Phase 2: Execute remaining agents (2-10) in parallel
-	var wg sync.WaitGroup
-	errors := make(chan error, 9)
+	// Phase 2: Execute remaining agents (2-10) in dependency-ordered waves.
+	// Agents within a wave run in parallel; each wave only starts once the
+	// results it consumes from earlier waves are available.
+	waves := [][]string{
+		{"agent_2", "agent_3", "agent_4", "agent_9"},
+		{"agent_5"},
+		{"agent_6", "agent_7"},
+		{"agent_8", "agent_10"},
+	}
 
 	agentFunctions := map[string]func(context.Context) error{
-		"agent_2": bo.executeAgent2,
-		"agent_3": bo.executeAgent3,
-		"agent_4": bo.executeAgent4,
-		"agent_5": bo.executeAgent5,
-		"agent_6": bo.executeAgent6,
-		"agent_7": bo.executeAgent7,
-		"agent_8": bo.executeAgent8,
-		"agent_9": bo.executeAgent9,
+		"agent_2":  bo.executeAgent2,
+		"agent_3":  bo.executeAgent3,
+		"agent_4":  bo.executeAgent4,
+		"agent_5":  bo.executeAgent5,
+		"agent_6":  bo.executeAgent6,
+		"agent_7":  bo.executeAgent7,
+		"agent_8":  bo.executeAgent8,
+		"agent_9":  bo.executeAgent9,
 		"agent_10": bo.executeAgent10,
 	}
 
-	// Launch all 9 agents in parallel
-	for agentID, agentFn := range agentFunctions {
-		wg.Add(1)
-		go func(id string, fn func(context.Context) error) {
-			defer wg.Done()
+	for _, wave := range waves {
+		var wg sync.WaitGroup
+		errors := make(chan error, len(wave))
 
-			startTime := time.Now()
-			bo.updateAgent(id, PhaseExecution, 0)
+		for _, agentID := range wave {
+			wg.Add(1)
+			go func(id string, fn func(context.Context) error) {
+				defer wg.Done()
 
-			err := fn(ctx)
-			if err != nil {
-				errors <- fmt.Errorf("%s: %w", id, err)
-			}
+				startTime := time.Now()
+				bo.updateAgent(id, PhaseExecution, 0)
 
-			duration := time.Since(startTime)
-			bo.mu.Lock()
-			bo.stageMetrics[id] = duration
-			bo.mu.Unlock()
-			bo.updateAgent(id, PhaseComplete, 1.0)
-		}(agentID, agentFn)
-	}
+				err := fn(ctx)
+				if err != nil {
+					errors <- fmt.Errorf("%s: %w", id, err)
+				}
+
+				duration := time.Since(startTime)
+				bo.mu.Lock()
+				bo.stageMetrics[id] = duration
+				bo.mu.Unlock()
+				bo.updateAgent(id, PhaseComplete, 1.0)
+			}(agentID, agentFunctions[agentID])
+		}
 
-	wg.Wait()
-	close(errors)
+		wg.Wait()
+		close(errors)
 
-	// Collect errors
-	for err := range errors {
-		if err != nil {
-			bo.mu.Lock()
-			bo.totalDuration = time.Since(bo.startTime)
-			bo.mu.Unlock()
-			return err
+		// Collect errors
+		for err := range errors {
+			if err != nil {
+				bo.mu.Lock()
+				bo.totalDuration = time.Since(bo.startTime)
+				bo.mu.Unlock()
+				return err
+			}
 		}
 	}
 
@@ -341,7 +377,11 @@ func (bo *BehaviorOrchestrator) executeAgent7(ctx context.Context) error {
 func (bo *BehaviorOrchestrator) executeAgent8(ctx context.Context) error {
 	bo.updateAgent("agent_8", PhaseExecution, 0)
 
-	mutationGen := NewMutationGenerator(bo.graph, time.Now().UnixNano())
+	seed := bo.config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	mutationGen := NewMutationGenerator(bo.graph, seed)
 	mutations, err := mutationGen.GenerateMutations(bo.config.MutationCount)
 	if err != nil {
 		return err
@@ -438,6 +478,164 @@ func (bo *BehaviorOrchestrator) updateAgent(agentID string, phase AgentPhase, pr
 	if agent, exists := bo.agents[agentID]; exists {
 		agent.Phase = phase
 		agent.Progress = progress
+
+		update := AgentStatusUpdate{
+			AgentID:   agentID,
+			Name:      agent.Name,
+			Phase:     phase,
+			Progress:  progress,
+			Timestamp: time.Now(),
+		}
+		for _, sub := range bo.subscribers {
+			sub.push(update)
+		}
+	}
+}
+
+// Subscribe returns a channel of agent status updates for the next
+// ExecuteAll. Updates are queued per subscriber so a slow consumer never
+// blocks the agents; the channel is closed once ExecuteAll returns and the
+// queued updates have been delivered. Consumers should drain it until closed,
+// or call the returned unsubscribe function to stop early, which drops any
+// queued updates and closes the channel. Close ends every subscription of an
+// orchestrator that will not run again.
+func (bo *BehaviorOrchestrator) Subscribe() (<-chan AgentStatusUpdate, func()) {
+	sub := newAgentSubscriber()
+
+	bo.mu.Lock()
+	defer bo.mu.Unlock()
+	bo.subscribers = append(bo.subscribers, sub)
+	return sub.out, func() { bo.unsubscribe(sub) }
+}
+
+// unsubscribe removes sub and stops its delivery goroutine
+func (bo *BehaviorOrchestrator) unsubscribe(sub *agentSubscriber) {
+	bo.mu.Lock()
+	for i, s := range bo.subscribers {
+		if s == sub {
+			bo.subscribers = append(bo.subscribers[:i], bo.subscribers[i+1:]...)
+			break
+		}
+	}
+	bo.mu.Unlock()
+
+	sub.stop()
+}
+
+// Close stops every current subscription without delivering queued
+// updates, releasing subscribers that will never see an ExecuteAll
+func (bo *BehaviorOrchestrator) Close() {
+	bo.mu.Lock()
+	subs := bo.subscribers
+	bo.subscribers = nil
+	bo.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+// closeSubscribers ends every current subscription once queued updates
+// have been delivered
+func (bo *BehaviorOrchestrator) closeSubscribers() {
+	bo.mu.Lock()
+	subs := bo.subscribers
+	bo.subscribers = nil
+	bo.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// agentSubscriber buffers updates in an unbounded queue and forwards them to
+// out from its own goroutine, so pushing never blocks
+type agentSubscriber struct {
+	mu       sync.Mutex
+	queue    []AgentStatusUpdate
+	closed   bool
+	notify   chan struct{}
+	out      chan AgentStatusUpdate
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newAgentSubscriber creates a subscriber and starts its delivery goroutine
+func newAgentSubscriber() *agentSubscriber {
+	sub := &agentSubscriber{
+		notify: make(chan struct{}, 1),
+		out:    make(chan AgentStatusUpdate),
+		done:   make(chan struct{}),
+	}
+	go sub.deliver()
+	return sub
+}
+
+// push queues an update; it is dropped if the subscription is closed
+func (s *agentSubscriber) push(update AgentStatusUpdate) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.queue = append(s.queue, update)
+	s.mu.Unlock()
+	s.wake()
+}
+
+// close stops accepting updates; queued ones are still delivered
+func (s *agentSubscriber) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.wake()
+}
+
+// stop ends the subscription immediately, dropping queued updates, even if
+// the consumer has stopped reading
+func (s *agentSubscriber) stop() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// wake signals deliver without blocking
+func (s *agentSubscriber) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// deliver forwards queued updates to out in order and closes out once the
+// subscription is closed and the queue is empty, or as soon as it is stopped
+func (s *agentSubscriber) deliver() {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return
+			}
+			select {
+			case <-s.notify:
+			case <-s.done:
+				return
+			}
+			continue
+		}
+		update := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		select {
+		case s.out <- update:
+		case <-s.done:
+			return
+		}
 	}
 }
 
@@ -468,13 +666,100 @@ func (bo *BehaviorOrchestrator) GetAgentStatus() map[string]*BehaviorAgent {
 	return status
 }
 
-// getInitialState returns the first node in the graph
+// getInitialState returns the first node in the graph by ID order
 func (bo *BehaviorOrchestrator) getInitialState() string {
 	bo.mu.RLock()
 	defer bo.mu.RUnlock()
 
+	initial := ""
 	for nodeID := range bo.graph.Nodes {
-		return nodeID
+		if initial == "" || nodeID < initial {
+			initial = nodeID
+		}
+	}
+	return initial
+}
+
+// defaultDeterminismSeed is used by RunDeterminismCheck when no seed is configured
+const defaultDeterminismSeed int64 = 42
+
+// RunDeterminismCheck executes the orchestration runs times against fresh copies
+// of the graph with a fixed seed and compares the sequence count, coverage percent
+// and mutation type distribution of each run. It returns whether all runs were
+// identical and the names of the fields that varied.
+func (bo *BehaviorOrchestrator) RunDeterminismCheck(ctx context.Context, runs int) (bool, []string) {
+	config := bo.config
+	if config.Seed == 0 {
+		config.Seed = defaultDeterminismSeed
+	}
+
+	var baseline *determinismFingerprint
+	varied := make(map[string]bool)
+	errs := make([]string, 0)
+
+	for i := 0; i < runs; i++ {
+		run := NewBehaviorOrchestrator(bo.graph.clone(), config)
+		if err := run.ExecuteAll(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("run %d: %v", i+1, err))
+			continue
+		}
+
+		fp := run.fingerprint()
+		if baseline == nil {
+			baseline = fp
+			continue
+		}
+		for _, field := range baseline.diff(fp) {
+			varied[field] = true
+		}
+	}
+
+	fields := make([]string, 0, len(varied)+len(errs))
+	for field := range varied {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	fields = append(fields, errs...)
+
+	return len(fields) == 0, fields
+}
+
+// determinismFingerprint captures the orchestration outputs compared across runs
+type determinismFingerprint struct {
+	sequenceCount   int
+	coveragePercent float64
+	mutationTypes   map[string]int
+}
+
+// fingerprint extracts the determinism-relevant outputs from the results
+func (bo *BehaviorOrchestrator) fingerprint() *determinismFingerprint {
+	bo.mu.RLock()
+	defer bo.mu.RUnlock()
+
+	fp := &determinismFingerprint{mutationTypes: make(map[string]int)}
+	fp.sequenceCount, _ = bo.results["sequences_generated"].(int)
+	if report, ok := bo.results["coverage_report"].(*CoverageReport); ok {
+		fp.coveragePercent = report.CoveragePercent
+	}
+	if stats, ok := bo.results["mutation_stats"].(map[string]interface{}); ok {
+		if dist, ok := stats["type_distribution"].(map[string]int); ok {
+			fp.mutationTypes = dist
+		}
+	}
+	return fp
+}
+
+// diff returns the names of the fields that differ between two fingerprints
+func (fp *determinismFingerprint) diff(other *determinismFingerprint) []string {
+	fields := make([]string, 0)
+	if fp.sequenceCount != other.sequenceCount {
+		fields = append(fields, "sequences_generated")
+	}
+	if fp.coveragePercent != other.coveragePercent {
+		fields = append(fields, "coverage_percent")
+	}
+	if !reflect.DeepEqual(fp.mutationTypes, other.mutationTypes) {
+		fields = append(fields, "mutation_type_distribution")
 	}
-	return ""
+	return fields
 }
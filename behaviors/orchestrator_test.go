@@ -0,0 +1,168 @@
+package behaviors
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunDeterminismCheck verifies fixed-seed orchestration runs are identical
+func TestRunDeterminismCheck(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+
+	config := OrchestratorConfig{
+		MaxConcurrency:   10,
+		TimeoutPerPhase:  30 * time.Second,
+		MaxSequenceDepth: 2,
+		MutationCount:    20,
+		Seed:             7,
+	}
+
+	orchestrator := NewBehaviorOrchestrator(graph, config)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	deterministic, varied := orchestrator.RunDeterminismCheck(ctx, 3)
+	if !deterministic {
+		t.Fatalf("Expected deterministic runs, fields varied: %v", varied)
+	}
+
+	if len(graph.Nodes) != 6 {
+		t.Errorf("Expected determinism check to leave the source graph untouched, got %d nodes", len(graph.Nodes))
+	}
+}
+
+// TestGraphValidationBeforeOrchestration verifies structural problems are
+// reported and abort execution in strict mode
+func TestGraphValidationBeforeOrchestration(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+	// Simulate a node removed after its edges were wired up
+	graph.Edges["idle"] = append(graph.Edges["idle"], &BehaviorEdge{From: "idle", To: "ghost", Weight: 1})
+
+	errs := graph.Validate()
+	found := false
+	for _, err := range errs {
+		if strings.Contains(err.Error(), "idle->ghost") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected dangling edge idle->ghost to be reported, got %v", errs)
+	}
+
+	config := OrchestratorConfig{
+		MaxConcurrency:   10,
+		TimeoutPerPhase:  30 * time.Second,
+		MaxSequenceDepth: 2,
+		MutationCount:    5,
+		StrictValidation: true,
+	}
+
+	orchestrator := NewBehaviorOrchestrator(graph, config)
+	if err := orchestrator.ExecuteAll(context.Background()); err == nil {
+		t.Fatal("Expected strict validation to abort orchestration, got nil")
+	}
+
+	results := orchestrator.GetResults()["agent_results"].(map[string]interface{})
+	if reported, ok := results["graph_validation_errors"].([]error); !ok || len(reported) == 0 {
+		t.Errorf("Expected validation errors in orchestrator results, got %v", results["graph_validation_errors"])
+	}
+	if _, ran := results["graph_nodes_count"]; ran {
+		t.Error("Expected agents not to run after strict validation failure")
+	}
+}
+
+// TestSubscribeReceivesAgentUpdates verifies live updates reach subscribers
+// and the channel closes when ExecuteAll returns
+func TestSubscribeReceivesAgentUpdates(t *testing.T) {
+	config := OrchestratorConfig{
+		MaxConcurrency:   10,
+		TimeoutPerPhase:  30 * time.Second,
+		MaxSequenceDepth: 2,
+		MutationCount:    5,
+		Seed:             7,
+	}
+	orchestrator := NewBehaviorOrchestrator(buildTestBehaviorGraph(), config)
+
+	collect := func(updates <-chan AgentStatusUpdate, done chan<- map[string]bool) {
+		completed := make(map[string]bool)
+		for update := range updates {
+			if update.Phase == PhaseComplete {
+				completed[update.AgentID] = true
+			}
+		}
+		done <- completed
+	}
+	first, second := make(chan map[string]bool, 1), make(chan map[string]bool, 1)
+	firstUpdates, _ := orchestrator.Subscribe()
+	secondUpdates, _ := orchestrator.Subscribe()
+	go collect(firstUpdates, first)
+	go collect(secondUpdates, second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := orchestrator.ExecuteAll(ctx); err != nil {
+		t.Fatalf("ExecuteAll failed: %v", err)
+	}
+
+	for i, done := range []chan map[string]bool{first, second} {
+		select {
+		case completed := <-done:
+			if len(completed) != 10 {
+				t.Errorf("Subscriber %d: expected completion updates for 10 agents, got %d: %v", i+1, len(completed), completed)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Subscriber %d: channel was not closed after ExecuteAll returned", i+1)
+		}
+	}
+}
+
+// TestSubscribeShutsDown verifies abandoned subscriptions release their
+// delivery goroutine via unsubscribe or Close
+func TestSubscribeShutsDown(t *testing.T) {
+	config := OrchestratorConfig{
+		MaxConcurrency:   10,
+		TimeoutPerPhase:  30 * time.Second,
+		MaxSequenceDepth: 2,
+		MutationCount:    5,
+		Seed:             7,
+	}
+	orchestrator := NewBehaviorOrchestrator(buildTestBehaviorGraph(), config)
+
+	waitClosed := func(name string, updates <-chan AgentStatusUpdate) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case _, ok := <-updates:
+				if !ok {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("%s: channel was not closed", name)
+			}
+		}
+	}
+
+	// A consumer that stops reading mid-run can still unsubscribe
+	stalled, unsubscribe := orchestrator.Subscribe()
+	orchestrator.updateAgent("agent_1", PhaseExecution, 0.5)
+	orchestrator.updateAgent("agent_1", PhaseComplete, 1.0)
+	<-stalled
+	unsubscribe()
+	unsubscribe()
+	waitClosed("unsubscribe", stalled)
+
+	// Subscribers of an orchestrator that never runs are released by Close
+	idle, _ := orchestrator.Subscribe()
+	orchestrator.Close()
+	waitClosed("Close", idle)
+
+	orchestrator.mu.RLock()
+	remaining := len(orchestrator.subscribers)
+	orchestrator.mu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("Expected no subscribers left, got %d", remaining)
+	}
+}
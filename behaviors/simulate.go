@@ -0,0 +1,130 @@
+// Package behaviors - Simulation entry point
+// Runs the full 10-agent pipeline on a single graph and returns a typed report
+package behaviors
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SimOptions configures a single-graph simulation. Zero values fall back to
+// the defaults used by the package examples.
+type SimOptions struct {
+	MaxConcurrency   int
+	MaxSequenceDepth int
+	MutationCount    int
+	Seed             int64         // Seeds the mutation generator; 0 uses the current time
+	Timeout          time.Duration // Overall simulation timeout; 0 means no extra timeout
+}
+
+// ValidationSummary aggregates per-behavior validation results
+type ValidationSummary struct {
+	Total    int
+	Valid    int
+	Invalid  int
+	Warnings int
+}
+
+// MutationSummary aggregates mutation generator statistics
+type MutationSummary struct {
+	Total            int
+	Applied          int
+	TypeDistribution map[string]int
+}
+
+// SimulationReport is the typed result of SimulateGraph
+type SimulationReport struct {
+	NodeCount      int
+	EdgeCount      int
+	SequenceCount  int
+	ExecutionCount int
+	Coverage       *CoverageReport
+	Performance    *PerformanceMetrics
+	Validation     ValidationSummary
+	Mutations      MutationSummary
+	Duration       time.Duration
+}
+
+// SimulateGraph runs every agent against bg and returns a single report.
+// The simulation runs on a copy of bg, so mutations never touch the caller's graph.
+func SimulateGraph(ctx context.Context, bg *BehaviorGraph, opts SimOptions) (*SimulationReport, error) {
+	if bg == nil {
+		return nil, fmt.Errorf("behavior graph is nil")
+	}
+
+	config := OrchestratorConfig{
+		MaxConcurrency:   opts.MaxConcurrency,
+		TimeoutPerPhase:  30 * time.Second,
+		MaxSequenceDepth: opts.MaxSequenceDepth,
+		MutationCount:    opts.MutationCount,
+		Seed:             opts.Seed,
+	}
+	if config.MaxConcurrency <= 0 {
+		config.MaxConcurrency = 10
+	}
+	if config.MaxSequenceDepth <= 0 {
+		config.MaxSequenceDepth = 5
+	}
+	if config.MutationCount <= 0 {
+		config.MutationCount = 20
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// Count the input graph before the mutation agent starts rewriting the copy
+	graph := bg.clone()
+	nodeCount, edgeCount := len(graph.Nodes), 0
+	for _, edges := range graph.Edges {
+		edgeCount += len(edges)
+	}
+
+	orchestrator := NewBehaviorOrchestrator(graph, config)
+	if err := orchestrator.ExecuteAll(ctx); err != nil {
+		return nil, err
+	}
+
+	report := orchestrator.report()
+	report.NodeCount = nodeCount
+	report.EdgeCount = edgeCount
+	return report, nil
+}
+
+// report converts the orchestrator's untyped results into a SimulationReport
+func (bo *BehaviorOrchestrator) report() *SimulationReport {
+	bo.mu.RLock()
+	defer bo.mu.RUnlock()
+
+	report := &SimulationReport{
+		Duration: bo.totalDuration,
+	}
+
+	report.SequenceCount, _ = bo.results["sequences_generated"].(int)
+	report.ExecutionCount, _ = bo.results["execution_count"].(int)
+	report.Coverage, _ = bo.results["coverage_report"].(*CoverageReport)
+	report.Performance, _ = bo.results["performance_metrics"].(*PerformanceMetrics)
+
+	if validations, ok := bo.results["validation_results"].([]*ValidationResult); ok {
+		for _, v := range validations {
+			report.Validation.Total++
+			if v.Valid {
+				report.Validation.Valid++
+			} else {
+				report.Validation.Invalid++
+			}
+			report.Validation.Warnings += len(v.Warnings)
+		}
+	}
+
+	if stats, ok := bo.results["mutation_stats"].(map[string]interface{}); ok {
+		report.Mutations.Total, _ = stats["total_mutations"].(int)
+		report.Mutations.Applied, _ = stats["applied_mutations"].(int)
+		report.Mutations.TypeDistribution, _ = stats["type_distribution"].(map[string]int)
+	}
+
+	return report
+}
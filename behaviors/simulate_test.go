@@ -0,0 +1,50 @@
+package behaviors
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSimulateGraph verifies the one-call entry point returns a populated report
+func TestSimulateGraph(t *testing.T) {
+	graph := buildTestBehaviorGraph()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	report, err := SimulateGraph(ctx, graph, SimOptions{
+		MaxSequenceDepth: 2,
+		MutationCount:    10,
+		Seed:             1,
+	})
+	if err != nil {
+		t.Fatalf("SimulateGraph failed: %v", err)
+	}
+
+	if report.NodeCount != 6 || report.EdgeCount != 8 {
+		t.Errorf("Expected 6 nodes and 8 edges, got %d and %d", report.NodeCount, report.EdgeCount)
+	}
+	if report.SequenceCount == 0 {
+		t.Error("Expected sequences to be generated")
+	}
+	if report.ExecutionCount == 0 {
+		t.Error("Expected sequences to be executed")
+	}
+	if report.Coverage == nil || report.Coverage.TotalNodes != 6 {
+		t.Errorf("Expected coverage report over 6 nodes, got %+v", report.Coverage)
+	}
+	if report.Performance == nil {
+		t.Error("Expected performance metrics")
+	}
+	if report.Validation.Total != 6 {
+		t.Errorf("Expected 6 validated behaviors, got %d", report.Validation.Total)
+	}
+	if report.Mutations.Total != 10 {
+		t.Errorf("Expected 10 mutations, got %d", report.Mutations.Total)
+	}
+
+	if len(graph.Nodes) != 6 {
+		t.Errorf("Expected caller's graph to be untouched, got %d nodes", len(graph.Nodes))
+	}
+}
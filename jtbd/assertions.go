@@ -46,6 +46,7 @@ type AssertionConstraint struct {
 	Min    interface{} `json:"min,omitempty"`
 	Max    interface{} `json:"max,omitempty"`
 	Strict bool        `json:"strict"` // Strict comparison vs fuzzy
+	Hard   bool        `json:"hard"`   // Hard constraints fail; soft ones only incur a penalty
 }
 
 // Expectations defines what constitutes success for a job.
@@ -55,6 +56,20 @@ type Expectations struct {
 	SocialCriteria     []string               `json:"social_criteria"`
 	Metrics            map[string]interface{} `json:"metrics"`
 	MaxDuration        time.Duration          `json:"max_duration"`
+	// MinSatisfaction is the fraction of emotional and social criteria that
+	// must be addressed by the job (0 means DefaultMinSatisfaction)
+	MinSatisfaction float64 `json:"min_satisfaction,omitempty"`
+}
+
+// DefaultMinSatisfaction is the lenient coverage required of emotional and
+// social criteria when Expectations.MinSatisfaction is unset.
+const DefaultMinSatisfaction = 0.5
+
+// SatisfactionScore reports the fraction of emotional and social criteria
+// addressed by a job's dimension text, from 0.0 to 1.0.
+type SatisfactionScore struct {
+	Emotional float64 `json:"emotional"`
+	Social    float64 `json:"social"`
 }
 
 // ProgressSnapshot represents a point-in-time progress measurement.
@@ -138,6 +153,76 @@ func (pt *ProgressTracker) AllIndicators() map[string]ProgressSnapshot {
 	return result
 }
 
+// latestSnapshot returns the most recently recorded snapshot, ignoring any
+// recorded before since. Ties on timestamp go to the lexicographically
+// greatest name.
+func (pt *ProgressTracker) latestSnapshot(since time.Time) (ProgressSnapshot, bool) {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	var latest ProgressSnapshot
+	var latestName string
+	found := false
+	for name, snapshot := range pt.snapshots {
+		if snapshot.Timestamp.Before(since) {
+			continue
+		}
+		if !found || snapshot.Timestamp.After(latest.Timestamp) ||
+			(snapshot.Timestamp.Equal(latest.Timestamp) && name > latestName) {
+			latest, latestName, found = snapshot, name, true
+		}
+	}
+	return latest, found
+}
+
+// ElapsedBetween returns the time elapsed from checkpoint a to checkpoint b.
+// The result is negative if b was recorded before a.
+func (pt *ProgressTracker) ElapsedBetween(a, b string) (time.Duration, error) {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	start, ok := pt.checkpoints[a]
+	if !ok {
+		return 0, fmt.Errorf("checkpoint '%s' not found", a)
+	}
+	end, ok := pt.checkpoints[b]
+	if !ok {
+		return 0, fmt.Errorf("checkpoint '%s' not found", b)
+	}
+	return end.Sub(start), nil
+}
+
+// Rate returns the per-second change of metric between two snapshots,
+// e.g. items added per second.
+func (pt *ProgressTracker) Rate(metric, fromSnapshot, toSnapshot string) (float64, error) {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	from, ok := pt.snapshots[fromSnapshot]
+	if !ok {
+		return 0, fmt.Errorf("snapshot '%s' not found", fromSnapshot)
+	}
+	to, ok := pt.snapshots[toSnapshot]
+	if !ok {
+		return 0, fmt.Errorf("snapshot '%s' not found", toSnapshot)
+	}
+
+	fromVal, ok := toFloat64(from.Values[metric])
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' is missing or non-numeric in snapshot '%s'", metric, fromSnapshot)
+	}
+	toVal, ok := toFloat64(to.Values[metric])
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' is missing or non-numeric in snapshot '%s'", metric, toSnapshot)
+	}
+
+	elapsed := to.Timestamp.Sub(from.Timestamp).Seconds()
+	if elapsed == 0 {
+		return 0, fmt.Errorf("snapshots '%s' and '%s' have the same timestamp", fromSnapshot, toSnapshot)
+	}
+	return (toVal - fromVal) / elapsed, nil
+}
+
 // AssertJobCompleted validates that a job was completed successfully.
 func AssertJobCompleted(ctx context.Context, job *Job) error {
 	if job == nil {
@@ -186,68 +271,168 @@ func AssertProgressMade(before, after ProgressSnapshot) error {
 // AssertWithinConstraints validates results against constraints.
 func AssertWithinConstraints(result Result, constraints []AssertionConstraint) error {
 	for _, constraint := range constraints {
-		value, exists := result.Data[constraint.Name]
-		if !exists {
-			return fmt.Errorf("constraint '%s' not found in result", constraint.Name)
+		if _, err := checkConstraint(result, constraint); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		switch constraint.Type {
-		case "max":
-			num, ok := toFloat64(value)
-			maxNum, maxOK := toFloat64(constraint.Value)
-			if !ok || !maxOK {
-				return fmt.Errorf("cannot compare non-numeric values for max constraint")
-			}
-			if num > maxNum {
-				return fmt.Errorf("'%s' exceeds max: %.2f > %.2f", constraint.Name, num, maxNum)
-			}
+// EvaluateConstraintsWithPenalty checks every constraint and returns a score in
+// [0, 1]. Hard constraint breaches are returned as errors. Soft breaches don't
+// fail but lower the score in proportion to how far the value is off. Malformed
+// constraints are always reported as errors.
+func EvaluateConstraintsWithPenalty(result Result, constraints []AssertionConstraint) (float64, []error) {
+	if len(constraints) == 0 {
+		return 1.0, nil
+	}
 
-		case "min":
-			num, ok := toFloat64(value)
-			minNum, minOK := toFloat64(constraint.Value)
-			if !ok || !minOK {
-				return fmt.Errorf("cannot compare non-numeric values for min constraint")
-			}
-			if num < minNum {
-				return fmt.Errorf("'%s' below min: %.2f < %.2f", constraint.Name, num, minNum)
-			}
+	var errs []error
+	var penalty float64
+	for _, constraint := range constraints {
+		breach, err := checkConstraint(result, constraint)
+		if err == nil {
+			continue
+		}
+		if breach == 0 || constraint.Hard {
+			errs = append(errs, err)
+			continue
+		}
+		penalty += breach
+	}
 
-		case "equals":
-			if value != constraint.Value {
-				return fmt.Errorf("'%s' does not equal expected: got %v, want %v",
-					constraint.Name, value, constraint.Value)
-			}
+	score := 1.0 - penalty/float64(len(constraints))
+	if score < 0 {
+		score = 0
+	}
+	return score, errs
+}
+
+// checkConstraint validates a single constraint. On a breach it returns the
+// breach magnitude in (0, 1] alongside the error; a zero magnitude with an
+// error means the constraint itself is malformed.
+func checkConstraint(result Result, constraint AssertionConstraint) (float64, error) {
+	value, exists := result.Data[constraint.Name]
+	if !exists {
+		return 1, fmt.Errorf("constraint '%s' not found in result", constraint.Name)
+	}
+
+	switch constraint.Type {
+	case "max":
+		num, ok := toFloat64(value)
+		maxNum, maxOK := toFloat64(constraint.Value)
+		if !ok || !maxOK {
+			return 0, fmt.Errorf("cannot compare non-numeric values for max constraint")
+		}
+		if num > maxNum {
+			return breachMagnitude(num-maxNum, maxNum),
+				fmt.Errorf("'%s' exceeds max: %.2f > %.2f", constraint.Name, num, maxNum)
+		}
+
+	case "min":
+		num, ok := toFloat64(value)
+		minNum, minOK := toFloat64(constraint.Value)
+		if !ok || !minOK {
+			return 0, fmt.Errorf("cannot compare non-numeric values for min constraint")
+		}
+		if num < minNum {
+			return breachMagnitude(minNum-num, minNum),
+				fmt.Errorf("'%s' below min: %.2f < %.2f", constraint.Name, num, minNum)
+		}
 
-		case "range":
-			num, ok := toFloat64(value)
-			minNum, minOK := toFloat64(constraint.Min)
-			maxNum, maxOK := toFloat64(constraint.Max)
-			if !ok || !minOK || !maxOK {
-				return fmt.Errorf("cannot perform range check on non-numeric values")
+	case "equals":
+		if value != constraint.Value {
+			return 1, fmt.Errorf("'%s' does not equal expected: got %v, want %v",
+				constraint.Name, value, constraint.Value)
+		}
+
+	case "range":
+		num, ok := toFloat64(value)
+		minNum, minOK := toFloat64(constraint.Min)
+		maxNum, maxOK := toFloat64(constraint.Max)
+		if !ok || !minOK || !maxOK {
+			return 0, fmt.Errorf("cannot perform range check on non-numeric values")
+		}
+		if num < minNum || num > maxNum {
+			over := num - maxNum
+			if num < minNum {
+				over = minNum - num
 			}
-			if num < minNum || num > maxNum {
-				return fmt.Errorf("'%s' out of range: %.2f not in [%.2f, %.2f]",
+			return breachMagnitude(over, maxNum-minNum),
+				fmt.Errorf("'%s' out of range: %.2f not in [%.2f, %.2f]",
 					constraint.Name, num, minNum, maxNum)
-			}
+		}
 
-		case "contains":
-			strValue, ok := value.(string)
-			strConstraint, cOK := constraint.Value.(string)
-			if !ok || !cOK {
-				return fmt.Errorf("'contains' constraint requires string values")
-			}
-			if !stringContains(strValue, strConstraint) {
-				return fmt.Errorf("'%s' does not contain '%s'", constraint.Name, strConstraint)
-			}
+	case "contains":
+		strValue, ok := value.(string)
+		strConstraint, cOK := constraint.Value.(string)
+		if !ok || !cOK {
+			return 0, fmt.Errorf("'contains' constraint requires string values")
+		}
+		if !stringContains(strValue, strConstraint) {
+			return 1, fmt.Errorf("'%s' does not contain '%s'", constraint.Name, strConstraint)
+		}
+
+	default:
+		return 0, fmt.Errorf("unknown constraint type: %s", constraint.Type)
+	}
+	return 0, nil
+}
+
+// breachMagnitude scales how far a value overshot relative to scale, capped at 1.
+func breachMagnitude(over, scale float64) float64 {
+	if scale < 0 {
+		scale = -scale
+	}
+	if scale == 0 {
+		return 1
+	}
+	m := over / scale
+	if m > 1 {
+		return 1
+	}
+	return m
+}
+
+// dimensionFillerWords appear in most dimension statements ("Feel ...",
+// "Be seen as ...") and so say nothing about whether a criterion is met
+var dimensionFillerWords = map[string]bool{
+	"feel": true, "be": true, "seen": true, "as": true, "about": true, "by": true,
+}
+
+// ScoreSatisfaction scores how many emotional and social criteria keywords
+// appear in the job's corresponding dimension. A criterion is addressed when
+// any of its meaningful words appears in the dimension text. Dimensions with
+// no criteria score 1.0.
+func ScoreSatisfaction(job *Job, expectations Expectations) SatisfactionScore {
+	return SatisfactionScore{
+		Emotional: criteriaCoverage(job.Emotional, expectations.EmotionalCriteria),
+		Social:    criteriaCoverage(job.Social, expectations.SocialCriteria),
+	}
+}
+
+// criteriaCoverage returns the fraction of criteria addressed by text
+func criteriaCoverage(text string, criteria []string) float64 {
+	if len(criteria) == 0 {
+		return 1.0
+	}
 
-		default:
-			return fmt.Errorf("unknown constraint type: %s", constraint.Type)
+	words := functionalWords(text)
+	addressed := 0
+	for _, criterion := range criteria {
+		for word := range functionalWords(criterion) {
+			if words[word] && !dimensionFillerWords[word] {
+				addressed++
+				break
+			}
 		}
 	}
-	return nil
+	return float64(addressed) / float64(len(criteria))
 }
 
 // AssertSatisfaction validates job satisfaction against expectations.
+// Emotional and social dimensions must address at least MinSatisfaction
+// of their criteria.
 func AssertSatisfaction(ctx context.Context, job *Job, expectations Expectations) error {
 	// Validate functional criteria
 	if job.Functional == "" && len(expectations.FunctionalCriteria) > 0 {
@@ -264,6 +449,20 @@ func AssertSatisfaction(ctx context.Context, job *Job, expectations Expectations
 		return fmt.Errorf("job has no social dimension but expectations require it")
 	}
 
+	minSatisfaction := expectations.MinSatisfaction
+	if minSatisfaction <= 0 {
+		minSatisfaction = DefaultMinSatisfaction
+	}
+	score := ScoreSatisfaction(job, expectations)
+	if score.Emotional < minSatisfaction {
+		return fmt.Errorf("emotional dimension addresses %.0f%% of criteria, need %.0f%%",
+			score.Emotional*100, minSatisfaction*100)
+	}
+	if score.Social < minSatisfaction {
+		return fmt.Errorf("social dimension addresses %.0f%% of criteria, need %.0f%%",
+			score.Social*100, minSatisfaction*100)
+	}
+
 	// Validate metrics
 	for metricName := range expectations.Metrics {
 		found := false
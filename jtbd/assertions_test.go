@@ -0,0 +1,118 @@
+package jtbd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker_Rate(t *testing.T) {
+	tracker := NewProgressTracker()
+
+	tracker.RecordProgress("start", map[string]interface{}{"items_added": 0, "label": "cart"})
+	time.Sleep(100 * time.Millisecond)
+	tracker.RecordProgress("end", map[string]interface{}{"items_added": 5, "label": "cart"})
+
+	rate, err := tracker.Rate("items_added", "start", "end")
+	if err != nil {
+		t.Fatalf("Rate failed: %v", err)
+	}
+
+	// 5 items over ~100ms is ~50/sec; allow for scheduler delay.
+	if rate <= 0 || rate > 50 {
+		t.Errorf("Expected rate in (0, 50] items/sec, got %.2f", rate)
+	}
+
+	if _, err := tracker.Rate("items_added", "start", "missing"); err == nil {
+		t.Error("Expected error for missing snapshot, got nil")
+	}
+	if _, err := tracker.Rate("label", "start", "end"); err == nil {
+		t.Error("Expected error for non-numeric metric, got nil")
+	}
+}
+
+func TestProgressTracker_ElapsedBetween(t *testing.T) {
+	tracker := NewProgressTracker()
+
+	tracker.RecordCheckpoint("begin")
+	time.Sleep(10 * time.Millisecond)
+	tracker.RecordCheckpoint("finish")
+
+	elapsed, err := tracker.ElapsedBetween("begin", "finish")
+	if err != nil {
+		t.Fatalf("ElapsedBetween failed: %v", err)
+	}
+	if elapsed < 10*time.Millisecond {
+		t.Errorf("Expected at least 10ms elapsed, got %v", elapsed)
+	}
+
+	if _, err := tracker.ElapsedBetween("begin", "missing"); err == nil {
+		t.Error("Expected error for missing checkpoint, got nil")
+	}
+}
+
+func TestEvaluateConstraintsWithPenalty(t *testing.T) {
+	result := Result{
+		JobID: "checkout",
+		Data: map[string]interface{}{
+			"checkout_time": 60.0,
+			"total_cost":    120.0,
+		},
+	}
+
+	// Soft breach: 60s against a 50s target is 20% over
+	score, errs := EvaluateConstraintsWithPenalty(result, []AssertionConstraint{
+		{Name: "checkout_time", Type: "max", Value: 50.0, Hard: false},
+	})
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors for soft breach, got %v", errs)
+	}
+	if score >= 1.0 || score < 0.79 {
+		t.Errorf("Expected score of about 0.8, got %.2f", score)
+	}
+
+	// Hard breach is reported as an error
+	_, errs = EvaluateConstraintsWithPenalty(result, []AssertionConstraint{
+		{Name: "total_cost", Type: "max", Value: 100.0, Hard: true},
+	})
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 error for hard breach, got %v", errs)
+	}
+
+	// Satisfied constraints keep a perfect score
+	score, errs = EvaluateConstraintsWithPenalty(result, []AssertionConstraint{
+		{Name: "total_cost", Type: "max", Value: 200.0, Hard: true},
+	})
+	if score != 1.0 || len(errs) != 0 {
+		t.Errorf("Expected (1.0, no errors), got (%.2f, %v)", score, errs)
+	}
+}
+
+func TestScoreSatisfaction_PartialEmotional(t *testing.T) {
+	job := &Job{
+		ID:        "gift",
+		Emotional: "Feel confident the recipient will love it",
+		Social:    "Be seen as thoughtful",
+	}
+	expectations := Expectations{
+		EmotionalCriteria: []string{"confident", "secure"},
+		SocialCriteria:    []string{"thoughtful"},
+	}
+
+	score := ScoreSatisfaction(job, expectations)
+	if score.Emotional != 0.5 {
+		t.Errorf("Expected partial emotional satisfaction 0.5, got %.2f", score.Emotional)
+	}
+	if score.Social != 1.0 {
+		t.Errorf("Expected full social satisfaction, got %.2f", score.Social)
+	}
+
+	if err := AssertSatisfaction(context.Background(), job, expectations); err != nil {
+		t.Errorf("Expected partial satisfaction to pass the default threshold, got %v", err)
+	}
+
+	expectations.MinSatisfaction = 0.75
+	if err := AssertSatisfaction(context.Background(), job, expectations); err == nil {
+		t.Error("Expected partial satisfaction to fail a 75% threshold")
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"claude-squad/jtbd"
+)
+
+// benchResult summarizes repeated executions of one test
+type benchResult struct {
+	TestID     string
+	Iterations int
+	Errors     int
+	Mean       time.Duration
+	Min        time.Duration
+	Max        time.Duration
+	Throughput float64 // executions per second of wall time
+}
+
+// runBenchmarks executes each test's Execute iterations times, spread over
+// concurrency workers, timing every call. Setup and Teardown run once per
+// test around the iterations and are not timed. Dependencies are ignored:
+// each test is measured on its own.
+func runBenchmarks(ctx context.Context, tests []*jtbd.Test, iterations, concurrency int) ([]benchResult, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("benchmark iterations must be positive, got %d", iterations)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]benchResult, 0, len(tests))
+	for _, test := range tests {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result, err := benchmarkTest(ctx, test, iterations, concurrency)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// benchmarkTest measures a single test
+func benchmarkTest(ctx context.Context, test *jtbd.Test, iterations, concurrency int) (benchResult, error) {
+	if test.Setup != nil {
+		if err := test.Setup(ctx); err != nil {
+			return benchResult{}, fmt.Errorf("setup for %s failed: %w", test.ID, err)
+		}
+	}
+	if test.Teardown != nil {
+		defer test.Teardown(ctx)
+	}
+
+	durations := make([]time.Duration, iterations)
+	errs := make([]bool, iterations)
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				durations[i], errs[i] = timeExecution(ctx, test)
+			}
+		}()
+	}
+	for i := 0; i < iterations; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	wall := time.Since(start)
+
+	result := benchResult{TestID: test.ID, Iterations: iterations, Min: durations[0]}
+	var total time.Duration
+	for i, d := range durations {
+		total += d
+		if d < result.Min {
+			result.Min = d
+		}
+		if d > result.Max {
+			result.Max = d
+		}
+		if errs[i] {
+			result.Errors++
+		}
+	}
+	result.Mean = total / time.Duration(iterations)
+	if wall > 0 {
+		result.Throughput = float64(iterations) / wall.Seconds()
+	}
+	return result, nil
+}
+
+// timeExecution runs Execute once under the test's timeout, reporting how
+// long it took and whether it failed
+func timeExecution(ctx context.Context, test *jtbd.Test) (time.Duration, bool) {
+	if test.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, test.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := test.Execute(ctx)
+	return time.Since(start), err != nil
+}
+
+// formatBenchResults renders benchmark results as an aligned table
+func formatBenchResults(results []benchResult) string {
+	var sb strings.Builder
+	sb.WriteString("JTBD Benchmarks\n")
+	sb.WriteString("===============\n\n")
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Test\tIterations\tErrors\tMean\tMin\tMax\tOps/sec")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%v\t%v\t%v\t%.1f\n",
+			r.TestID, r.Iterations, r.Errors, r.Mean, r.Min, r.Max, r.Throughput)
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"claude-squad/jtbd"
+)
+
+// loadDurationHistory reads per-test durations from a results file written by
+// a previous run with -format json.
+func loadDurationHistory(path string) (map[string]time.Duration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous jtbd.TestResults
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to parse history file: %w", err)
+	}
+
+	history := make(map[string]time.Duration, len(previous.Results))
+	for _, result := range previous.Results {
+		if result.Status == jtbd.TestStatusSkipped {
+			continue
+		}
+		history[result.TestID] = result.Duration
+	}
+	return history, nil
+}
+
+// selectWithinBudget greedily picks the highest-priority tests whose estimated
+// durations fit in budget. Estimates come from history, falling back to the
+// test's own timeout. A test is only picked once all its dependencies are.
+// Tests that are not picked are returned as skipped results.
+func selectWithinBudget(tests []*jtbd.Test, history map[string]time.Duration, budget time.Duration) ([]*jtbd.Test, []*jtbd.ExecutionResult) {
+	candidates := make([]*jtbd.Test, len(tests))
+	copy(candidates, tests)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Priority > candidates[j].Priority
+	})
+
+	estimate := func(test *jtbd.Test) time.Duration {
+		if d, ok := history[test.ID]; ok {
+			return d
+		}
+		return test.Timeout
+	}
+
+	selected := make(map[string]bool)
+	reasons := make(map[string]string)
+	var chosen []*jtbd.Test
+	var used time.Duration
+
+	// Repeat until stable so a dependency listed after its dependent can still
+	// unlock it on a later pass.
+	for changed := true; changed; {
+		changed = false
+		for _, test := range candidates {
+			if selected[test.ID] {
+				continue
+			}
+
+			depsSelected := true
+			for _, dep := range test.Dependencies {
+				if !selected[dep] {
+					depsSelected = false
+					break
+				}
+			}
+			if !depsSelected {
+				reasons[test.ID] = "dependencies not selected within time budget"
+				continue
+			}
+
+			cost := estimate(test)
+			if used+cost > budget {
+				reasons[test.ID] = fmt.Sprintf("estimated %v exceeds remaining time budget %v", cost, budget-used)
+				continue
+			}
+
+			selected[test.ID] = true
+			chosen = append(chosen, test)
+			used += cost
+			changed = true
+		}
+	}
+
+	var skipped []*jtbd.ExecutionResult
+	now := time.Now()
+	for _, test := range tests {
+		if selected[test.ID] {
+			continue
+		}
+		skipped = append(skipped, &jtbd.ExecutionResult{
+			TestID:     test.ID,
+			Status:     jtbd.TestStatusSkipped,
+			SkipReason: reasons[test.ID],
+			StartTime:  now,
+			EndTime:    now,
+		})
+	}
+
+	return chosen, skipped
+}
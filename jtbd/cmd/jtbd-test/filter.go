@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"claude-squad/jtbd"
+)
+
+// errNoTestsMatched reports a -filter pattern that selected no tests, which
+// is a usage error rather than an empty, passing run
+var errNoTestsMatched = errors.New("no tests match -filter pattern")
+
+// filterTests keeps the tests whose ID matches pattern, plus their
+// dependencies so the execution plan stays valid. An empty pattern keeps
+// every test; a pattern matching none is an error.
+func filterTests(tests []*jtbd.Test, pattern string) ([]*jtbd.Test, error) {
+	if pattern == "" {
+		return tests, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -filter pattern %q: %w", pattern, err)
+	}
+
+	var matched []string
+	for _, test := range tests {
+		if re.MatchString(test.ID) {
+			matched = append(matched, test.ID)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w %q", errNoTestsMatched, pattern)
+	}
+	return withDependencies(tests, matched), nil
+}
+
+// withDependencies returns the tests named by ids together with their
+// transitive dependencies, in their original order. Unknown IDs are ignored.
+func withDependencies(tests []*jtbd.Test, ids []string) []*jtbd.Test {
+	byID := make(map[string]*jtbd.Test, len(tests))
+	for _, test := range tests {
+		byID[test.ID] = test
+	}
+
+	picked := make(map[string]bool, len(ids))
+	var include func(id string)
+	include = func(id string) {
+		test, ok := byID[id]
+		if !ok || picked[id] {
+			return
+		}
+		picked[id] = true
+		for _, depID := range test.Dependencies {
+			include(depID)
+		}
+	}
+	for _, id := range ids {
+		include(id)
+	}
+
+	selected := make([]*jtbd.Test, 0, len(picked))
+	for _, test := range tests {
+		if picked[test.ID] {
+			selected = append(selected, test)
+		}
+	}
+	return selected
+}
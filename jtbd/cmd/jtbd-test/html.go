@@ -0,0 +1,57 @@
+package main
+
+import (
+	"html/template"
+	"strings"
+
+	"claude-squad/jtbd"
+)
+
+// htmlReport is a self-contained results page. Statuses double as CSS
+// classes for the row colors.
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<title>JTBD Test Results</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.summary span { margin-right: 1.5em; font-weight: bold; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+tr.passed { background: #e6f4ea; }
+tr.failed { background: #fce8e6; }
+tr.skipped { background: #f1f3f4; }
+tr.quarantined { background: #fef7e0; }
+pre { margin: 0; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>JTBD Test Results</h1>
+<div class="summary">
+<span>Total: {{.Metrics.Total}}</span>
+<span>Passed: {{.Metrics.Passed}}</span>
+<span>Failed: {{.Metrics.Failed}}</span>
+<span>Skipped: {{.Metrics.Skipped}}</span>
+<span>Quarantined: {{.Metrics.Quarantined}}</span>
+</div>
+<table>
+<tr><th>Test</th><th>Status</th><th>Duration</th><th>Retries</th><th>Detail</th></tr>
+{{- range .Results}}
+<tr class="{{.Status}}"><td>{{.TestID}}</td><td>{{.Status}}</td><td>{{.Duration}}</td><td>{{.RetryCount}}</td><td>
+{{- if .ErrorMessage}}<pre>{{.ErrorMessage}}</pre>{{else if .SkipReason}}{{.SkipReason}}{{end -}}
+</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// formatHTMLResults renders results as a standalone HTML page
+func formatHTMLResults(results *jtbd.TestResults) (string, error) {
+	var sb strings.Builder
+	if err := htmlReport.Execute(&sb, results); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
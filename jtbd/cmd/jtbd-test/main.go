@@ -3,10 +3,17 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"flag"
 	"fmt"
+	"math/rand"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,17 +25,25 @@ var (
 	industry      = flag.String("industry", "", "Run tests for specific industry (saas, ecommerce, manufacturing, healthcare, fintech, education, realstate, logistics, hospitality, retail)")
 	listIndustries = flag.Bool("list", false, "List supported industries")
 	outputFile    = flag.String("output", "", "Write results to file (use '-' for stdout)")
-	outputFormat  = flag.String("format", "text", "Output format: text, json, junit")
+	outputFormat  = flag.String("format", "text", "Output format: text, json, junit, tap, html, csv")
 	verbose       = flag.Bool("v", false, "Verbose output")
 	timeout       = flag.Duration("timeout", 5*time.Minute, "Test timeout")
 	parallel      = flag.Int("parallel", 4, "Number of parallel test processes")
-	coverage      = flag.Bool("coverage", false, "Generate coverage report")
-	failCoverage  = flag.Bool("fail-coverage", false, "Fail if coverage below threshold")
+	coverage      = flag.Bool("coverage", false, "Print coverage: the percentage of planned tests that executed")
+	failCoverage  = flag.Bool("fail-coverage", false, "Exit with code 2 if coverage is below -min-coverage")
 	minCoverage   = flag.Float64("min-coverage", 70.0, "Minimum coverage percentage")
-	runBench      = flag.Bool("bench", false, "Run benchmarks")
+	minPassRate   = flag.Float64("min-pass-rate", 100.0, "Minimum percentage of tests that must pass")
+	runBench      = flag.Bool("bench", false, "Benchmark each test instead of running the suite once")
+	benchIters    = flag.Int("bench-iterations", 10, "Executions per test when benchmarking")
 	retry         = flag.Bool("retry", false, "Retry failed tests")
 	maxRetries    = flag.Int("max-retries", 2, "Maximum retry attempts")
 	ciMode        = flag.Bool("ci", false, "Enable CI mode")
+	timeBudget    = flag.Duration("time-budget", 0, "Run only the highest-priority tests that fit this budget (0 disables)")
+	historyFile   = flag.String("history", "", "Results file from a previous -format json run, used to estimate test durations for -time-budget")
+	seedFlag      = flag.Int64("seed", 0, "Seed for all randomness, to replay a previous run (0 picks one and prints it)")
+	filterFlag    = flag.String("filter", "", "Run only tests whose ID matches this regular expression, plus their dependencies")
+	sampleSize    = flag.Int("sample", 0, "Run a random sample of this many tests plus their dependencies (0 runs all)")
+	generate      = flag.Bool("generate", false, "Add test cases generated from industry patterns; their IDs follow -seed")
 )
 
 var supportedIndustries = []string{
@@ -66,8 +81,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	if _, err := regexp.Compile(*filterFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --filter pattern: %v\n", err)
+		os.Exit(1)
+	}
+
+	seed := resolveSeed(*seedFlag)
+	fmt.Fprintf(os.Stderr, "Using seed %d (replay with -seed %d)\n", seed, seed)
+
+	if *runBench {
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		tests, err := buildTests(seed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		benchResults, err := runBenchmarks(ctx, tests, *benchIters, *parallel)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running benchmarks: %v\n", err)
+			os.Exit(127)
+		}
+		fmt.Print(formatBenchResults(benchResults))
+		os.Exit(0)
+	}
+
 	// Run tests
-	results, err := runTests()
+	results, err := runTests(seed)
+	if errors.Is(err, errNoTestsMatched) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running tests: %v\n", err)
 		os.Exit(127)
@@ -79,8 +123,18 @@ func main() {
 		os.Exit(127)
 	}
 
+	if *coverage {
+		executed := results.Metrics.Total - results.Metrics.Skipped
+		fmt.Fprintf(os.Stderr, "Coverage: %.1f%% (%d/%d tests executed)\n",
+			testCoverage(results.Metrics), executed, results.Metrics.Total)
+	}
+
 	// Determine exit code
-	exitCode := calculateExitCode(results)
+	requiredCoverage := 0.0
+	if *failCoverage {
+		requiredCoverage = *minCoverage
+	}
+	exitCode := calculateExitCode(results, *minPassRate, requiredCoverage)
 	os.Exit(exitCode)
 }
 
@@ -93,25 +147,40 @@ func isValidIndustry(ind string) bool {
 	return false
 }
 
-func runTests() (*jtbd.TestResults, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+func runTests(seed int64) (*jtbd.TestResults, error) {
+	runTimeout := *timeout
+
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
 	defer cancel()
 
 	config := &jtbd.RunConfig{
 		Mode:          jtbd.ExecutionModeParallel,
 		MaxWorkers:    *parallel,
-		GlobalTimeout: *timeout,
-		TestTimeout:   *timeout / 10,
+		GlobalTimeout: runTimeout,
+		TestTimeout:   runTimeout / 10,
 		EnableRetry:   *retry,
 		IsolateTests:  true,
+		Seed:          seed,
+		StartBudget:   *timeBudget,
 	}
 
-	var tests []*jtbd.Test
+	tests, err := buildTests(seed)
+	if err != nil {
+		return nil, err
+	}
 
-	if *runAll {
-		tests = createAllTests()
-	} else {
-		tests = createIndustryTests(*industry)
+	// Without history tests are started in priority order until the budget
+	// runs out, and the rest are skipped.
+	var budgetSkipped []*jtbd.ExecutionResult
+	if *timeBudget > 0 && *historyFile != "" {
+		history, err := loadDurationHistory(*historyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load history: %w", err)
+		}
+		tests, budgetSkipped = selectWithinBudget(tests, history, *timeBudget)
+		if len(tests) == 0 {
+			return nil, fmt.Errorf("no tests fit within time budget %v", *timeBudget)
+		}
 	}
 
 	engine, err := jtbd.NewExecutionEngine(tests, config)
@@ -126,19 +195,45 @@ func runTests() (*jtbd.TestResults, error) {
 
 	select {
 	case <-ctx.Done():
-		return nil, fmt.Errorf("tests timed out after %v", *timeout)
+		return nil, fmt.Errorf("tests timed out after %v", runTimeout)
 	default:
 	}
 
 	metrics := engine.GetMetrics()
+	execResults = append(execResults, budgetSkipped...)
+	metrics.Total += int32(len(budgetSkipped))
+	metrics.Skipped += int32(len(budgetSkipped))
+	metrics.BudgetSkipped += int32(len(budgetSkipped))
 
 	return &jtbd.TestResults{
 		Results: execResults,
 		Metrics: metrics,
-		Duration: *timeout,
+		Duration: runTimeout,
 	}, nil
 }
 
+// buildTests creates the selected tests, adds generated cases for -generate,
+// narrows them with -filter and applies -sample, all using seed, so the same
+// seed always yields the same tests.
+func buildTests(seed int64) ([]*jtbd.Test, error) {
+	var tests []*jtbd.Test
+	industries := []string{*industry}
+	if *runAll {
+		tests = createAllTests()
+		industries = supportedIndustries
+	} else {
+		tests = createIndustryTests(*industry)
+	}
+	if *generate {
+		tests = append(tests, generatedTests(industries, seed)...)
+	}
+	tests, err := filterTests(tests, *filterFlag)
+	if err != nil {
+		return nil, err
+	}
+	return sampleTests(tests, *sampleSize, rand.New(rand.NewSource(seed))), nil
+}
+
 func createAllTests() []*jtbd.Test {
 	var tests []*jtbd.Test
 	for _, ind := range supportedIndustries {
@@ -192,6 +287,18 @@ func outputResults(results *jtbd.TestResults) error {
 		output = string(data)
 	case "junit":
 		output = formatJUnitResults(results)
+	case "tap":
+		output = formatTAPResults(results)
+	case "html":
+		output, err = formatHTMLResults(results)
+		if err != nil {
+			return err
+		}
+	case "csv":
+		output, err = formatCSVResults(results)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown format: %s", *outputFormat)
 	}
@@ -214,6 +321,7 @@ func formatTextResults(results *jtbd.TestResults) string {
 	sb.WriteString(fmt.Sprintf("Passed:        %d\n", results.Metrics.Passed))
 	sb.WriteString(fmt.Sprintf("Failed:        %d\n", results.Metrics.Failed))
 	sb.WriteString(fmt.Sprintf("Skipped:       %d\n", results.Metrics.Skipped))
+	sb.WriteString(fmt.Sprintf("Quarantined:   %d\n", results.Metrics.Quarantined))
 	sb.WriteString(fmt.Sprintf("Retry Attempts: %d\n\n", results.Metrics.Retries))
 
 	if len(results.Results) > 0 {
@@ -224,6 +332,9 @@ func formatTextResults(results *jtbd.TestResults) string {
 				status = "✗"
 			} else if result.Status == jtbd.TestStatusSkipped {
 				status = "○"
+			} else if result.Status == jtbd.TestStatusQuarantined {
+				// Listed separately below
+				continue
 			}
 			sb.WriteString(fmt.Sprintf("  %s %s (%v)\n", status, result.TestID, result.Duration))
 			if result.ErrorMessage != "" {
@@ -232,6 +343,41 @@ func formatTextResults(results *jtbd.TestResults) string {
 		}
 	}
 
+	if categories := results.CategorizeFailures(); len(categories) > 0 {
+		signatures := make([]string, 0, len(categories))
+		for signature := range categories {
+			signatures = append(signatures, signature)
+		}
+		// Largest buckets first: they are the likeliest root causes
+		sort.Slice(signatures, func(i, j int) bool {
+			a, b := signatures[i], signatures[j]
+			if len(categories[a]) != len(categories[b]) {
+				return len(categories[a]) > len(categories[b])
+			}
+			return a < b
+		})
+
+		sb.WriteString("\nFailure Categories:\n")
+		for _, signature := range signatures {
+			ids := categories[signature]
+			sb.WriteString(fmt.Sprintf("  [%d] %s\n", len(ids), signature))
+			sb.WriteString(fmt.Sprintf("      %s\n", strings.Join(ids, ", ")))
+		}
+	}
+
+	if results.Metrics.Quarantined > 0 {
+		sb.WriteString("\nQuarantined Failures (not counted as failed):\n")
+		for _, result := range results.Results {
+			if result.Status != jtbd.TestStatusQuarantined {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  ! %s (%v)\n", result.TestID, result.Duration))
+			if result.ErrorMessage != "" {
+				sb.WriteString(fmt.Sprintf("      Error: %s\n", result.ErrorMessage))
+			}
+		}
+	}
+
 	return sb.String()
 }
 
@@ -245,11 +391,14 @@ func formatJUnitResults(results *jtbd.TestResults) string {
 
 	for _, result := range results.Results {
 		sb.WriteString(fmt.Sprintf(`    <testcase name="%s" time="%.3f">`,
-			result.TestID, result.Duration.Seconds()))
+			xmlEscape(result.TestID), result.Duration.Seconds()))
+		writeOutcomeProperties(&sb, result.OutcomeResults)
 		if result.Status == jtbd.TestStatusFailed {
-			sb.WriteString(fmt.Sprintf(`<failure message="%s"/>`, result.ErrorMessage))
+			sb.WriteString(fmt.Sprintf(`<failure message="%s"/>`, xmlEscape(result.ErrorMessage)))
 		} else if result.Status == jtbd.TestStatusSkipped {
-			sb.WriteString(fmt.Sprintf(`<skipped message="%s"/>`, result.SkipReason))
+			sb.WriteString(fmt.Sprintf(`<skipped message="%s"/>`, xmlEscape(result.SkipReason)))
+		} else if result.Status == jtbd.TestStatusQuarantined {
+			sb.WriteString(fmt.Sprintf(`<system-out>quarantined failure: %s</system-out>`, xmlEscape(result.ErrorMessage)))
 		}
 		sb.WriteString(`</testcase>` + "\n")
 	}
@@ -260,9 +409,150 @@ func formatJUnitResults(results *jtbd.TestResults) string {
 	return sb.String()
 }
 
-func calculateExitCode(results *jtbd.TestResults) int {
-	if results.Metrics.Failed > 0 {
-		return 1
+// formatTAPResults renders results as TAP version 13, one test point per
+// result. Skips carry a SKIP directive and quarantined failures a TODO
+// directive, so TAP consumers don't count either as failures.
+func formatTAPResults(results *jtbd.TestResults) string {
+	var sb strings.Builder
+
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", len(results.Results)))
+
+	for i, result := range results.Results {
+		description := strings.ReplaceAll(result.TestID, "#", `\#`)
+		switch result.Status {
+		case jtbd.TestStatusPassed:
+			sb.WriteString(fmt.Sprintf("ok %d - %s\n", i+1, description))
+		case jtbd.TestStatusSkipped:
+			sb.WriteString(fmt.Sprintf("ok %d - %s # SKIP %s\n", i+1, description, tapDirectiveText(result.SkipReason)))
+		case jtbd.TestStatusQuarantined:
+			sb.WriteString(fmt.Sprintf("not ok %d - %s # TODO quarantined\n", i+1, description))
+			writeTAPDiagnostic(&sb, result.ErrorMessage)
+		default:
+			sb.WriteString(fmt.Sprintf("not ok %d - %s\n", i+1, description))
+			writeTAPDiagnostic(&sb, result.ErrorMessage)
+		}
+	}
+
+	return sb.String()
+}
+
+// csvHeader is the first row of CSV output, written even for an empty run
+var csvHeader = []string{"test_id", "status", "duration_ms", "retry_count", "error_message"}
+
+// formatCSVResults renders one CSV row per result after a header row
+func formatCSVResults(results *jtbd.TestResults) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	if err := w.Write(csvHeader); err != nil {
+		return "", err
+	}
+	for _, result := range results.Results {
+		record := []string{
+			result.TestID,
+			string(result.Status),
+			strconv.FormatFloat(float64(result.Duration)/float64(time.Millisecond), 'f', 3, 64),
+			strconv.Itoa(result.RetryCount),
+			result.ErrorMessage,
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+// tapDirectiveText keeps a directive's reason on the test point's line
+func tapDirectiveText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writeTAPDiagnostic writes an error message as a TAP 13 YAML block
+func writeTAPDiagnostic(sb *strings.Builder, message string) {
+	if message == "" {
+		return
+	}
+	sb.WriteString("  ---\n")
+	sb.WriteString(fmt.Sprintf("  message: %s\n", strconv.Quote(message)))
+	sb.WriteString("  ...\n")
+}
+
+// writeOutcomeProperties writes a testcase's outcomes as JUnit properties
+// named outcome.<metric>.<field>. CI systems that don't chart them ignore them.
+func writeOutcomeProperties(sb *strings.Builder, outcomes []*jtbd.OutcomeResult) {
+	if len(outcomes) == 0 {
+		return
+	}
+
+	sb.WriteString(`<properties>`)
+	for _, outcome := range outcomes {
+		prefix := "outcome." + outcome.MetricName
+		writeProperty(sb, prefix+".unit", outcome.Unit)
+		writeProperty(sb, prefix+".target", strconv.FormatFloat(outcome.TargetValue, 'g', -1, 64))
+		writeProperty(sb, prefix+".actual", strconv.FormatFloat(outcome.ActualValue, 'g', -1, 64))
+		writeProperty(sb, prefix+".threshold", strconv.FormatFloat(outcome.ThresholdValue, 'g', -1, 64))
+		writeProperty(sb, prefix+".met", strconv.FormatBool(outcome.MetTarget))
+	}
+	sb.WriteString(`</properties>`)
+}
+
+// writeProperty writes one escaped JUnit property element
+func writeProperty(sb *strings.Builder, name, value string) {
+	sb.WriteString(fmt.Sprintf(`<property name="%s" value="%s"/>`, xmlEscape(name), xmlEscape(value)))
+}
+
+// xmlEscape escapes s for use in XML text or a double-quoted attribute
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// calculateExitCode returns 1 when the observed pass rate falls below
+// minPassRate. The rate is Passed/Total, so tests that never ran (for
+// example those skipped after a fail-fast abort) count against it rather
+// than being excluded. Quarantined failures and tests left out to fit
+// -time-budget are left out of the total entirely. An empty run is treated
+// as a 100% pass rate.
+//
+// When coverage falls below minCoverage and no test actually failed, it
+// returns exitCoverageFailed instead, so CI can tell untested code from
+// failing tests. This is checked before the pass rate because skipped tests
+// also lower the pass rate, which would otherwise always mask low coverage
+// under the default thresholds. A minCoverage of zero disables the check.
+func calculateExitCode(results *jtbd.TestResults, minPassRate, minCoverage float64) int {
+	if results.Metrics.Failed == 0 && testCoverage(results.Metrics) < minCoverage {
+		return exitCoverageFailed
+	}
+	if passRate(results.Metrics) < minPassRate {
+		return exitTestsFailed
 	}
 	return 0
 }
+
+// Exit codes for runs that completed but did not meet their thresholds
+const (
+	exitTestsFailed    = 1
+	exitCoverageFailed = 2
+)
+
+// testCoverage returns the percentage of planned tests that executed rather
+// than being skipped. An empty run is treated as full coverage.
+func testCoverage(metrics jtbd.TestMetrics) float64 {
+	if metrics.Total <= 0 {
+		return 100.0
+	}
+	return float64(metrics.Total-metrics.Skipped) / float64(metrics.Total) * 100.0
+}
+
+// passRate returns the percentage of tests that passed.
+func passRate(metrics jtbd.TestMetrics) float64 {
+	total := metrics.Total - metrics.Quarantined - metrics.BudgetSkipped
+	if total <= 0 {
+		return 100.0
+	}
+	return float64(metrics.Passed) / float64(total) * 100.0
+}
@@ -0,0 +1,673 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-squad/jtbd"
+)
+
+var update = flag.Bool("update", false, "Rewrite golden files with current output")
+
+// CompareGolden compares got against the golden file at goldenPath. With
+// update set it rewrites the golden file instead.
+func CompareGolden(t *testing.T, got []byte, goldenPath string, update bool) {
+	t.Helper()
+
+	if update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatalf("Failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Output does not match %s (run with -update to accept):\n%s", goldenPath, lineDiff(string(want), string(got)))
+	}
+}
+
+// lineDiff renders a simple line-by-line diff of want and got.
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	n := len(wantLines)
+	if len(gotLines) > n {
+		n = len(gotLines)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("line %d:\n  - %s\n  + %s\n", i+1, w, g))
+	}
+	return sb.String()
+}
+
+// fixedClockResults runs a small suite with a fake clock so durations and
+// timestamps are identical on every run.
+func fixedClockResults(t *testing.T) *jtbd.TestResults {
+	t.Helper()
+
+	clock := jtbd.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	tests := []*jtbd.Test{
+		{ID: "retail-test-1", Execute: func(ctx context.Context) error { return nil }},
+		{ID: "retail-test-2", Execute: func(ctx context.Context) error { return errors.New("checkout total mismatch") }},
+	}
+
+	engine, err := jtbd.NewExecutionEngine(tests, &jtbd.RunConfig{
+		Mode:          jtbd.ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		Clock:         clock,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	return &jtbd.TestResults{Results: results, Metrics: engine.GetMetrics()}
+}
+
+func TestFormatTextResults_Golden(t *testing.T) {
+	got := formatTextResults(fixedClockResults(t))
+	CompareGolden(t, []byte(got), filepath.Join("testdata", "results.txt.golden"), *update)
+}
+
+func TestCalculateExitCode_MinPassRate(t *testing.T) {
+	results := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 10, Passed: 9, Failed: 1},
+	}
+
+	if code := calculateExitCode(results, 85.0, 0); code != 0 {
+		t.Errorf("Expected exit code 0 at 85%% threshold, got %d", code)
+	}
+
+	if code := calculateExitCode(results, 95.0, 0); code != 1 {
+		t.Errorf("Expected exit code 1 at 95%% threshold, got %d", code)
+	}
+}
+
+func TestCalculateExitCode_DefaultRequiresAllPassing(t *testing.T) {
+	passing := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 4, Passed: 4},
+	}
+	if code := calculateExitCode(passing, 100.0, 0); code != 0 {
+		t.Errorf("Expected exit code 0 with all tests passing, got %d", code)
+	}
+
+	failing := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 4, Passed: 3, Failed: 1},
+	}
+	if code := calculateExitCode(failing, 100.0, 0); code != 1 {
+		t.Errorf("Expected exit code 1 with a failing test, got %d", code)
+	}
+}
+
+func TestCalculateExitCode_IgnoresQuarantined(t *testing.T) {
+	results := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 3, Passed: 2, Quarantined: 1},
+	}
+
+	if code := calculateExitCode(results, 100.0, 0); code != 0 {
+		t.Errorf("Expected exit code 0 when only quarantined tests fail, got %d", code)
+	}
+}
+
+func TestCalculateExitCode_MinCoverage(t *testing.T) {
+	// Every test that ran passed, but most were skipped
+	results := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 10, Passed: 3, Skipped: 7},
+	}
+
+	if cov := testCoverage(results.Metrics); cov != 30.0 {
+		t.Errorf("Expected 30%% coverage, got %.1f%%", cov)
+	}
+	if code := calculateExitCode(results, 0, 70.0); code != exitCoverageFailed {
+		t.Errorf("Expected coverage exit code %d, got %d", exitCoverageFailed, code)
+	}
+	if code := calculateExitCode(results, 0, 0); code != 0 {
+		t.Errorf("Expected exit code 0 with the coverage check disabled, got %d", code)
+	}
+
+	// With the default thresholds the skips lower the pass rate too, but
+	// they are reported as a coverage failure
+	if code := calculateExitCode(results, 100.0, 70.0); code != exitCoverageFailed {
+		t.Errorf("Expected coverage exit code %d with default thresholds, got %d", exitCoverageFailed, code)
+	}
+
+	// A real test failure takes precedence over low coverage
+	failing := &jtbd.TestResults{
+		Metrics: jtbd.TestMetrics{Total: 10, Passed: 2, Failed: 1, Skipped: 7},
+	}
+	if code := calculateExitCode(failing, 100.0, 70.0); code != exitTestsFailed {
+		t.Errorf("Expected test-failure exit code %d, got %d", exitTestsFailed, code)
+	}
+}
+
+func TestSelectWithinBudget(t *testing.T) {
+	tests := []*jtbd.Test{
+		{ID: "low", Priority: 1, Timeout: time.Minute},
+		{ID: "high", Priority: 10, Timeout: time.Minute},
+		{ID: "medium", Priority: 5, Timeout: time.Minute},
+		{ID: "high-dependent", Priority: 10, Timeout: time.Minute, Dependencies: []string{"low"}},
+	}
+	history := map[string]time.Duration{
+		"low":            20 * time.Second,
+		"high":           30 * time.Second,
+		"medium":         25 * time.Second,
+		"high-dependent": 5 * time.Second,
+	}
+
+	chosen, skipped := selectWithinBudget(tests, history, 60*time.Second)
+
+	var total time.Duration
+	ids := make(map[string]bool)
+	for _, test := range chosen {
+		ids[test.ID] = true
+		total += history[test.ID]
+	}
+
+	if total > 60*time.Second {
+		t.Errorf("Expected chosen tests to fit in 60s, got %v", total)
+	}
+	if !ids["high"] || !ids["medium"] {
+		t.Errorf("Expected high and medium priority tests to be chosen, got %v", ids)
+	}
+	if ids["high-dependent"] {
+		t.Error("Expected high-dependent to be skipped since its dependency was not chosen")
+	}
+	if len(chosen)+len(skipped) != len(tests) {
+		t.Errorf("Expected every test to be chosen or skipped, got %d + %d", len(chosen), len(skipped))
+	}
+	for _, result := range skipped {
+		if result.Status != jtbd.TestStatusSkipped || result.SkipReason == "" {
+			t.Errorf("Expected %s to be skipped with a reason, got %+v", result.TestID, result)
+		}
+	}
+}
+
+func TestBuildTests_SameSeedSameSample(t *testing.T) {
+	defer func(all bool, n int) { *runAll, *sampleSize = all, n }(*runAll, *sampleSize)
+	*runAll, *sampleSize = true, 5
+
+	ids := func(seed int64) string {
+		var out []string
+		for _, test := range mustBuildTests(t, seed) {
+			out = append(out, test.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	first, second := ids(42), ids(42)
+	if first != second {
+		t.Errorf("Expected the same seed to select the same tests:\n%s\n%s", first, second)
+	}
+	if first == ids(7) && first == ids(99) {
+		t.Error("Expected different seeds to select different samples")
+	}
+
+	// Sampled tests bring their dependencies with them
+	selected := make(map[string]bool)
+	for _, test := range mustBuildTests(t, 42) {
+		selected[test.ID] = true
+	}
+	for _, test := range mustBuildTests(t, 42) {
+		for _, dep := range test.Dependencies {
+			if !selected[dep] {
+				t.Errorf("Expected dependency %s of %s in the sample", dep, test.ID)
+			}
+		}
+	}
+}
+
+func TestBuildTests_SameSeedSameGeneratedCases(t *testing.T) {
+	defer func(all, gen bool, n int) { *runAll, *generate, *sampleSize = all, gen, n }(*runAll, *generate, *sampleSize)
+	*runAll, *generate, *sampleSize = true, true, 0
+
+	generatedIDs := func(seed int64) string {
+		var out []string
+		for _, test := range mustBuildTests(t, seed) {
+			if strings.HasPrefix(test.ID, "TC-") {
+				out = append(out, test.ID)
+			}
+		}
+		return strings.Join(out, ",")
+	}
+
+	first, second := generatedIDs(42), generatedIDs(42)
+	if first == "" {
+		t.Fatal("Expected -generate to add generated test cases")
+	}
+	if first != second {
+		t.Errorf("Expected the same seed to generate the same case IDs:\n%s\n%s", first, second)
+	}
+	if first == generatedIDs(7) {
+		t.Error("Expected a different seed to generate different case IDs")
+	}
+
+	// Generated cases are measured at their targets, so they pass
+	var generated []*jtbd.Test
+	for _, test := range mustBuildTests(t, 42) {
+		if strings.HasPrefix(test.ID, "TC-") {
+			generated = append(generated, test)
+		}
+	}
+	engine, err := jtbd.NewExecutionEngine(generated, &jtbd.RunConfig{
+		Mode:          jtbd.ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 30 * time.Second,
+		TestTimeout:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for _, result := range results {
+		if result.Status != jtbd.TestStatusPassed {
+			t.Errorf("Expected generated case %s to pass, got %s: %s", result.TestID, result.Status, result.ErrorMessage)
+		}
+	}
+}
+
+func TestBuildTests_FilterPullsInDependencies(t *testing.T) {
+	defer func(all bool, pattern string) { *runAll, *filterFlag = all, pattern }(*runAll, *filterFlag)
+	*runAll = true
+
+	ids := func(pattern string) string {
+		*filterFlag = pattern
+		var out []string
+		for _, test := range mustBuildTests(t, 1) {
+			out = append(out, test.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := ids("retail.*"); got != "retail-test-1,retail-test-2" {
+		t.Errorf("Expected only retail tests, got %s", got)
+	}
+	// retail-test-2 depends on retail-test-1
+	if got := ids("^retail-test-2$"); got != "retail-test-1,retail-test-2" {
+		t.Errorf("Expected retail-test-2 plus its dependency, got %s", got)
+	}
+
+	*filterFlag = "retail("
+	if _, err := buildTests(1); err == nil || !strings.Contains(err.Error(), "invalid -filter pattern") {
+		t.Errorf("Expected invalid pattern error, got %v", err)
+	}
+
+	// A valid pattern that matches nothing must not become an empty, passing run
+	*filterFlag = "^no-such-test$"
+	if _, err := buildTests(1); !errors.Is(err, errNoTestsMatched) {
+		t.Errorf("Expected no-match error, got %v", err)
+	}
+}
+
+// mustBuildTests calls buildTests, failing the test on error
+func mustBuildTests(t *testing.T, seed int64) []*jtbd.Test {
+	t.Helper()
+	tests, err := buildTests(seed)
+	if err != nil {
+		t.Fatalf("buildTests failed: %v", err)
+	}
+	return tests
+}
+
+func TestFormatJUnitResults_OutcomeProperties(t *testing.T) {
+	results := &jtbd.TestResults{
+		Results: []*jtbd.ExecutionResult{
+			{
+				TestID: "checkout-speed",
+				Status: jtbd.TestStatusPassed,
+				OutcomeResults: []*jtbd.OutcomeResult{
+					{MetricName: "checkout_seconds", Unit: "seconds", TargetValue: 30, ActualValue: 24.5, ThresholdValue: 45, MetTarget: true},
+				},
+			},
+			{TestID: "no-outcomes", Status: jtbd.TestStatusPassed},
+		},
+		Metrics: jtbd.TestMetrics{Total: 2, Passed: 2},
+	}
+
+	var suites struct {
+		Suites []struct {
+			Cases []struct {
+				Name       string `xml:"name,attr"`
+				Properties []struct {
+					Name  string `xml:"name,attr"`
+					Value string `xml:"value,attr"`
+				} `xml:"properties>property"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal([]byte(formatJUnitResults(results)), &suites); err != nil {
+		t.Fatalf("JUnit output is not valid XML: %v", err)
+	}
+	if len(suites.Suites) != 1 || len(suites.Suites[0].Cases) != 2 {
+		t.Fatalf("Expected 1 suite with 2 testcases, got %+v", suites)
+	}
+
+	props := make(map[string]string)
+	for _, p := range suites.Suites[0].Cases[0].Properties {
+		props[p.Name] = p.Value
+	}
+	want := map[string]string{
+		"outcome.checkout_seconds.unit":      "seconds",
+		"outcome.checkout_seconds.target":    "30",
+		"outcome.checkout_seconds.actual":    "24.5",
+		"outcome.checkout_seconds.threshold": "45",
+		"outcome.checkout_seconds.met":       "true",
+	}
+	for name, value := range want {
+		if props[name] != value {
+			t.Errorf("Expected property %s=%s, got %q", name, value, props[name])
+		}
+	}
+	if len(suites.Suites[0].Cases[1].Properties) != 0 {
+		t.Error("Expected no properties for a testcase without outcomes")
+	}
+}
+
+func TestFormatTAPResults(t *testing.T) {
+	results := &jtbd.TestResults{
+		Results: []*jtbd.ExecutionResult{
+			{TestID: "retail-test-1", Status: jtbd.TestStatusPassed},
+			{TestID: "retail-test-2", Status: jtbd.TestStatusFailed, ErrorMessage: "checkout total mismatch"},
+			{TestID: "retail-test-3", Status: jtbd.TestStatusSkipped, SkipReason: "dependencies failed"},
+			{TestID: "retail-test-4", Status: jtbd.TestStatusQuarantined, ErrorMessage: "flaky"},
+		},
+		Metrics: jtbd.TestMetrics{Total: 3, Passed: 1, Failed: 1, Skipped: 1, Quarantined: 1},
+	}
+
+	lines := strings.Split(formatTAPResults(results), "\n")
+	if lines[0] != "TAP version 13" {
+		t.Errorf("Expected TAP version header, got %q", lines[0])
+	}
+	if lines[1] != "1..4" {
+		t.Errorf("Expected plan 1..4, got %q", lines[1])
+	}
+
+	points := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "ok ") || strings.HasPrefix(line, "not ok ") {
+			points++
+		}
+	}
+	if points != len(results.Results) {
+		t.Errorf("Expected %d test points to match the plan, got %d", len(results.Results), points)
+	}
+
+	for _, want := range []string{
+		"ok 1 - retail-test-1",
+		"not ok 2 - retail-test-2",
+		`  message: "checkout total mismatch"`,
+		"ok 3 - retail-test-3 # SKIP dependencies failed",
+		"not ok 4 - retail-test-4 # TODO quarantined",
+	} {
+		found := false
+		for _, line := range lines {
+			if line == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected line %q in TAP output", want)
+		}
+	}
+}
+
+func TestFormatJUnitResults_EscapesSpecialCharacters(t *testing.T) {
+	message := `expected "quotes" & <tags>`
+	results := &jtbd.TestResults{
+		Results: []*jtbd.ExecutionResult{
+			{TestID: "a<b>&c", Status: jtbd.TestStatusFailed, ErrorMessage: message},
+			{TestID: "skipped", Status: jtbd.TestStatusSkipped, SkipReason: `needs "a" & <b>`},
+			{TestID: "quarantined", Status: jtbd.TestStatusQuarantined, ErrorMessage: message},
+		},
+		Metrics: jtbd.TestMetrics{Total: 2, Failed: 1, Skipped: 1, Quarantined: 1},
+	}
+
+	var suites struct {
+		Suites []struct {
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal([]byte(formatJUnitResults(results)), &suites); err != nil {
+		t.Fatalf("JUnit output is not valid XML: %v", err)
+	}
+	if len(suites.Suites) != 1 || len(suites.Suites[0].Cases) != 3 {
+		t.Fatalf("Expected 1 suite with 3 testcases, got %+v", suites)
+	}
+
+	cases := suites.Suites[0].Cases
+	if cases[0].Name != "a<b>&c" {
+		t.Errorf("Expected testcase name to round-trip, got %q", cases[0].Name)
+	}
+	if cases[0].Failure == nil || cases[0].Failure.Message != message {
+		t.Errorf("Expected failure message %q, got %+v", message, cases[0].Failure)
+	}
+	if cases[1].Skipped == nil || cases[1].Skipped.Message != `needs "a" & <b>` {
+		t.Errorf("Expected skip reason to round-trip, got %+v", cases[1].Skipped)
+	}
+	if cases[2].SystemOut != "quarantined failure: "+message {
+		t.Errorf("Expected quarantined output to round-trip, got %q", cases[2].SystemOut)
+	}
+}
+
+func TestFormatHTMLResults(t *testing.T) {
+	results := &jtbd.TestResults{
+		Results: []*jtbd.ExecutionResult{
+			{TestID: "retail-test-1", Status: jtbd.TestStatusPassed},
+			{TestID: "retail-test-2", Status: jtbd.TestStatusFailed, ErrorMessage: "<script>alert(1)</script>"},
+			{TestID: "retail-test-3", Status: jtbd.TestStatusSkipped, SkipReason: "dependencies failed"},
+		},
+		Metrics: jtbd.TestMetrics{Total: 3, Passed: 1, Failed: 1, Skipped: 1},
+	}
+
+	got, err := formatHTMLResults(results)
+	if err != nil {
+		t.Fatalf("formatHTMLResults failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<table>",
+		"Total: 3",
+		"Passed: 1",
+		"Failed: 1",
+		"Skipped: 1",
+		`<tr class="failed"><td>retail-test-2</td>`,
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		"dependencies failed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected HTML output to contain %q", want)
+		}
+	}
+	if strings.Contains(got, "<script>") {
+		t.Error("Expected error messages to be escaped")
+	}
+}
+
+func TestFormatCSVResults(t *testing.T) {
+	results := &jtbd.TestResults{
+		Results: []*jtbd.ExecutionResult{
+			{TestID: "retail-test-1", Status: jtbd.TestStatusPassed, Duration: 1500 * time.Microsecond},
+			{TestID: "retail-test-2", Status: jtbd.TestStatusFailed, RetryCount: 2, ErrorMessage: `total "42", expected 40`},
+		},
+		Metrics: jtbd.TestMetrics{Total: 2, Passed: 1, Failed: 1},
+	}
+
+	got, err := formatCSVResults(results)
+	if err != nil {
+		t.Fatalf("formatCSVResults failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(got)).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV output does not parse: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header plus 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != "test_id,status,duration_ms,retry_count,error_message" {
+		t.Errorf("Unexpected header %v", records[0])
+	}
+	for i, record := range records {
+		if len(record) != 5 {
+			t.Errorf("Record %d: expected 5 fields, got %d", i, len(record))
+		}
+	}
+	if records[1][2] != "1.500" {
+		t.Errorf("Expected duration_ms 1.500, got %q", records[1][2])
+	}
+	if records[2][3] != "2" || records[2][4] != `total "42", expected 40` {
+		t.Errorf("Expected retry count and error message to round-trip, got %v", records[2])
+	}
+
+	empty, err := formatCSVResults(&jtbd.TestResults{})
+	if err != nil {
+		t.Fatalf("formatCSVResults failed: %v", err)
+	}
+	if empty != "test_id,status,duration_ms,retry_count,error_message\n" {
+		t.Errorf("Expected only the header for an empty run, got %q", empty)
+	}
+}
+
+func TestRunBenchmarks(t *testing.T) {
+	setups := 0
+	tests := []*jtbd.Test{
+		{
+			ID:      "sleepy",
+			Setup:   func(ctx context.Context) error { setups++; return nil },
+			Execute: func(ctx context.Context) error { time.Sleep(time.Millisecond); return nil },
+		},
+		{
+			ID:      "failing",
+			Execute: func(ctx context.Context) error { time.Sleep(time.Millisecond); return errors.New("boom") },
+		},
+	}
+
+	results, err := runBenchmarks(context.Background(), tests, 6, 3)
+	if err != nil {
+		t.Fatalf("runBenchmarks failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 benchmark results, got %d", len(results))
+	}
+	if setups != 1 {
+		t.Errorf("Expected setup to run once, ran %d times", setups)
+	}
+
+	for _, r := range results {
+		if r.Iterations != 6 {
+			t.Errorf("%s: expected 6 iterations, got %d", r.TestID, r.Iterations)
+		}
+		if r.Mean < time.Millisecond || r.Min <= 0 || r.Max < r.Min {
+			t.Errorf("%s: expected non-zero timings, got mean=%v min=%v max=%v", r.TestID, r.Mean, r.Min, r.Max)
+		}
+		if r.Throughput <= 0 {
+			t.Errorf("%s: expected positive throughput, got %v", r.TestID, r.Throughput)
+		}
+	}
+	if results[1].Errors != 6 {
+		t.Errorf("Expected 6 errors for the failing test, got %d", results[1].Errors)
+	}
+
+	table := formatBenchResults(results)
+	if !strings.Contains(table, "sleepy") || !strings.Contains(table, "Ops/sec") {
+		t.Errorf("Expected benchmark table with test rows, got:\n%s", table)
+	}
+
+	if _, err := runBenchmarks(context.Background(), tests, 0, 1); err == nil {
+		t.Error("Expected error for zero iterations")
+	}
+}
+
+func TestRunTests_TimeBudgetExitCode(t *testing.T) {
+	defer func(ind string, all bool, budget time.Duration, history string, workers int) {
+		*industry, *runAll, *timeBudget, *historyFile, *parallel = ind, all, budget, history, workers
+	}(*industry, *runAll, *timeBudget, *historyFile, *parallel)
+	*industry, *runAll, *parallel = "retail", false, 1
+
+	check := func(t *testing.T) {
+		t.Helper()
+		results, err := runTests(1)
+		if err != nil {
+			t.Fatalf("runTests failed: %v", err)
+		}
+		if results.Metrics.Passed != 1 || results.Metrics.BudgetSkipped != 1 {
+			t.Fatalf("Expected one passed and one budget-skipped test, got %+v", results.Metrics)
+		}
+		if code := calculateExitCode(results, 100.0, 0); code != 0 {
+			t.Errorf("Expected exit code 0 when only the budget dropped tests, got %d", code)
+		}
+	}
+
+	t.Run("without history", func(t *testing.T) {
+		// retail-test-1 starts inside the budget; retail-test-2 would start after it
+		*timeBudget, *historyFile = 50*time.Millisecond, ""
+		check(t)
+	})
+
+	t.Run("with history", func(t *testing.T) {
+		history := &jtbd.TestResults{Results: []*jtbd.ExecutionResult{
+			{TestID: "retail-test-1", Status: jtbd.TestStatusPassed, Duration: 100 * time.Millisecond},
+			{TestID: "retail-test-2", Status: jtbd.TestStatusPassed, Duration: 10 * time.Second},
+		}}
+		data, err := json.Marshal(history)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		path := filepath.Join(t.TempDir(), "history.json")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+
+		*timeBudget, *historyFile = time.Second, path
+		check(t)
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"claude-squad/jtbd"
+)
+
+// resolveSeed returns seed, or a time-based seed when seed is zero, so every
+// run has a seed that can be printed and replayed.
+func resolveSeed(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return time.Now().UnixNano()
+}
+
+// sampleTests picks n tests at random using rng, adding any dependencies of
+// the picked tests so the sample can run on its own. Tests keep their
+// original order. A non-positive n, or one covering every test, returns
+// tests unchanged.
+func sampleTests(tests []*jtbd.Test, n int, rng *rand.Rand) []*jtbd.Test {
+	if n <= 0 || n >= len(tests) {
+		return tests
+	}
+
+	ids := make([]string, 0, n)
+	for _, i := range rng.Perm(len(tests))[:n] {
+		ids = append(ids, tests[i].ID)
+	}
+	return withDependencies(tests, ids)
+}
+
+// generatedTests turns happy-path JTBD test cases for industries into tests.
+// Case IDs are drawn from a source seeded with seed, so the same seed
+// generates the same IDs. Each case is measured exactly at its outcome
+// target, making the tests placeholders like the hand-written ones.
+// Industries without a generator pattern contribute no tests.
+func generatedTests(industries []string, seed int64) []*jtbd.Test {
+	rng := rand.New(rand.NewSource(seed))
+	gen := jtbd.NewTestCaseGenerator()
+	gen.WithIDGenerator(func() string {
+		return fmt.Sprintf("TC-%08x", rng.Uint32())
+	})
+
+	var tests []*jtbd.Test
+	for _, ind := range industries {
+		for _, tc := range gen.GenerateTestCases(ind, jtbd.TestGenerationOptions{IncludeHappyPath: true}) {
+			measured := map[string]float64{string(tc.OutcomeSpec.Type): tc.OutcomeSpec.Target}
+			tests = append(tests, tc.ToTest(measured))
+		}
+	}
+	return tests
+}
@@ -0,0 +1,287 @@
+package jtbd
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ComparisonVerdict summarizes how a candidate performed against a baseline
+type ComparisonVerdict string
+
+const (
+	// VerdictImproved means the candidate is better on every compared metric that changed
+	VerdictImproved ComparisonVerdict = "improved"
+
+	// VerdictRegressed means the candidate is worse on every compared metric that changed
+	VerdictRegressed ComparisonVerdict = "regressed"
+
+	// VerdictMixed means some metrics improved while others regressed
+	VerdictMixed ComparisonVerdict = "mixed"
+
+	// VerdictUnchanged means no compared metric changed
+	VerdictUnchanged ComparisonVerdict = "unchanged"
+)
+
+// MetricComparison compares a single metric shared by two jobs
+type MetricComparison struct {
+	// Metric is the name of the compared metric
+	Metric string
+
+	// Direction is "minimize" or "maximize", taken from the baseline outcome
+	Direction string
+
+	// BaselineValue is the measured value for the baseline job
+	BaselineValue float64
+
+	// CandidateValue is the measured value for the candidate job
+	CandidateValue float64
+
+	// ImprovementPercent is the relative change, positive when the candidate is better
+	ImprovementPercent float64
+
+	// Verdict is VerdictImproved, VerdictRegressed or VerdictUnchanged
+	Verdict ComparisonVerdict
+}
+
+// OutcomeComparison is the head-to-head result of comparing two jobs' outcomes
+type OutcomeComparison struct {
+	// BaselineJobID is the ID of the baseline job
+	BaselineJobID string
+
+	// CandidateJobID is the ID of the candidate job
+	CandidateJobID string
+
+	// Metrics contains a comparison for each shared, measured metric, sorted by name
+	Metrics []*MetricComparison
+
+	// BaselineOnly lists metrics defined only by the baseline job
+	BaselineOnly []string
+
+	// CandidateOnly lists metrics defined only by the candidate job
+	CandidateOnly []string
+
+	// Unmeasured lists shared metrics missing a measurement for either job
+	Unmeasured []string
+
+	// Verdict is the overall verdict across all compared metrics
+	Verdict ComparisonVerdict
+}
+
+// CompareOutcomes compares measured outcome values of a candidate job against a
+// baseline job. Metrics are matched by Outcome.Metric and compared in the
+// direction declared by the baseline outcome.
+func CompareOutcomes(baseline, candidate *Job, measuredBaseline, measuredCandidate map[string]float64) *OutcomeComparison {
+	comparison := &OutcomeComparison{Verdict: VerdictUnchanged}
+	if baseline == nil || candidate == nil {
+		return comparison
+	}
+	comparison.BaselineJobID = baseline.ID
+	comparison.CandidateJobID = candidate.ID
+
+	baselineOutcomes := outcomesByMetric(baseline)
+	candidateOutcomes := outcomesByMetric(candidate)
+
+	improved, regressed := 0, 0
+	for metric, outcome := range baselineOutcomes {
+		if _, shared := candidateOutcomes[metric]; !shared {
+			comparison.BaselineOnly = append(comparison.BaselineOnly, metric)
+			continue
+		}
+
+		baseVal, baseOK := measuredBaseline[metric]
+		candVal, candOK := measuredCandidate[metric]
+		if !baseOK || !candOK {
+			comparison.Unmeasured = append(comparison.Unmeasured, metric)
+			continue
+		}
+
+		mc := &MetricComparison{
+			Metric:         metric,
+			Direction:      outcomeDirection(outcome),
+			BaselineValue:  baseVal,
+			CandidateValue: candVal,
+			Verdict:        VerdictUnchanged,
+		}
+
+		gain := candVal - baseVal
+		if mc.Direction == "minimize" {
+			gain = -gain
+		}
+		if baseVal != 0 {
+			mc.ImprovementPercent = gain / math.Abs(baseVal) * 100
+		}
+
+		switch {
+		case gain > 0:
+			mc.Verdict = VerdictImproved
+			improved++
+		case gain < 0:
+			mc.Verdict = VerdictRegressed
+			regressed++
+		}
+		comparison.Metrics = append(comparison.Metrics, mc)
+	}
+
+	for metric := range candidateOutcomes {
+		if _, shared := baselineOutcomes[metric]; !shared {
+			comparison.CandidateOnly = append(comparison.CandidateOnly, metric)
+		}
+	}
+
+	sort.Slice(comparison.Metrics, func(i, j int) bool {
+		return comparison.Metrics[i].Metric < comparison.Metrics[j].Metric
+	})
+	sort.Strings(comparison.BaselineOnly)
+	sort.Strings(comparison.CandidateOnly)
+	sort.Strings(comparison.Unmeasured)
+
+	switch {
+	case improved > 0 && regressed > 0:
+		comparison.Verdict = VerdictMixed
+	case improved > 0:
+		comparison.Verdict = VerdictImproved
+	case regressed > 0:
+		comparison.Verdict = VerdictRegressed
+	}
+
+	return comparison
+}
+
+// outcomesByMetric indexes a job's outcomes by metric name
+func outcomesByMetric(job *Job) map[string]*Outcome {
+	outcomes := make(map[string]*Outcome, len(job.Outcomes))
+	for _, outcome := range job.Outcomes {
+		if outcome != nil && outcome.Metric != "" {
+			outcomes[outcome.Metric] = outcome
+		}
+	}
+	return outcomes
+}
+
+// outcomeDirection returns the outcome's declared direction, falling back to
+// "minimize" for speed and cost outcomes and "maximize" for everything else
+func outcomeDirection(outcome *Outcome) string {
+	if outcome.Direction == "minimize" || outcome.Direction == "maximize" {
+		return outcome.Direction
+	}
+	if outcome.Type == OutcomeTypeSpeed || outcome.Type == OutcomeTypeCost {
+		return "minimize"
+	}
+	return "maximize"
+}
+
+// functionalMatchThreshold is the minimum word overlap (Jaccard similarity)
+// for two functional statements to count as the same intent
+const functionalMatchThreshold = 0.5
+
+// functionalStopWords are ignored when matching functional statements
+var functionalStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "for": true, "to": true, "of": true,
+	"and": true, "or": true, "in": true, "on": true, "my": true, "with": true,
+}
+
+// FunctionalGroup collects jobs from different companies that share a functional intent
+type FunctionalGroup struct {
+	// Intent is the functional statement of the first job in the group
+	Intent string
+
+	// Jobs are the grouped jobs in input order
+	Jobs []*Job
+
+	// Companies lists each company in the group once, in input order
+	Companies []string
+
+	// Targets maps metric -> company -> outcome target
+	Targets map[string]map[string]float64
+}
+
+// CompanyComparison is a side-by-side view of jobs grouped by functional intent
+type CompanyComparison struct {
+	Groups []*FunctionalGroup
+}
+
+// CompareJobsAcrossCompanies groups jobs whose Functional statements describe
+// the same need and tabulates each company's outcome targets per metric.
+// Jobs without a match form their own group.
+func CompareJobsAcrossCompanies(jobs []*Job) *CompanyComparison {
+	comparison := &CompanyComparison{}
+	var groupWords []map[string]bool
+
+	for _, job := range jobs {
+		if job == nil {
+			continue
+		}
+		words := functionalWords(job.Functional)
+
+		var group *FunctionalGroup
+		for i, candidate := range comparison.Groups {
+			if len(words) > 0 && jaccard(words, groupWords[i]) >= functionalMatchThreshold {
+				group = candidate
+				break
+			}
+		}
+		if group == nil {
+			group = &FunctionalGroup{
+				Intent:  job.Functional,
+				Targets: make(map[string]map[string]float64),
+			}
+			comparison.Groups = append(comparison.Groups, group)
+			groupWords = append(groupWords, words)
+		}
+
+		group.Jobs = append(group.Jobs, job)
+		if !containsString(group.Companies, job.Company) {
+			group.Companies = append(group.Companies, job.Company)
+		}
+		for _, outcome := range job.Outcomes {
+			if outcome == nil || outcome.Metric == "" {
+				continue
+			}
+			if group.Targets[outcome.Metric] == nil {
+				group.Targets[outcome.Metric] = make(map[string]float64)
+			}
+			group.Targets[outcome.Metric][job.Company] = outcome.Target
+		}
+	}
+
+	return comparison
+}
+
+// functionalWords returns the lowercased significant words of a functional statement
+func functionalWords(functional string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(functional), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !functionalStopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// jaccard returns the Jaccard similarity of two word sets
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package jtbd
+
+import "testing"
+
+func TestCompareOutcomes_CandidateFaster(t *testing.T) {
+	baseline := &Job{
+		ID:   "checkout-v1",
+		Name: "Checkout v1",
+		Outcomes: []*Outcome{
+			{Type: OutcomeTypeSpeed, Metric: "checkout_time", Direction: "minimize"},
+			{Type: OutcomeTypeQuality, Metric: "legacy_score"},
+		},
+	}
+	candidate := &Job{
+		ID:   "checkout-v2",
+		Name: "Checkout v2",
+		Outcomes: []*Outcome{
+			{Type: OutcomeTypeSpeed, Metric: "checkout_time", Direction: "minimize"},
+			{Type: OutcomeTypeExperience, Metric: "satisfaction"},
+		},
+	}
+
+	comparison := CompareOutcomes(baseline, candidate,
+		map[string]float64{"checkout_time": 120},
+		map[string]float64{"checkout_time": 90},
+	)
+
+	if comparison.Verdict != VerdictImproved {
+		t.Errorf("Expected verdict %s, got %s", VerdictImproved, comparison.Verdict)
+	}
+
+	if len(comparison.Metrics) != 1 {
+		t.Fatalf("Expected 1 compared metric, got %d", len(comparison.Metrics))
+	}
+	mc := comparison.Metrics[0]
+	if mc.Verdict != VerdictImproved {
+		t.Errorf("Expected checkout_time verdict %s, got %s", VerdictImproved, mc.Verdict)
+	}
+	if mc.ImprovementPercent != 25 {
+		t.Errorf("Expected 25%% improvement, got %.2f", mc.ImprovementPercent)
+	}
+
+	if len(comparison.BaselineOnly) != 1 || comparison.BaselineOnly[0] != "legacy_score" {
+		t.Errorf("Expected baseline-only [legacy_score], got %v", comparison.BaselineOnly)
+	}
+	if len(comparison.CandidateOnly) != 1 || comparison.CandidateOnly[0] != "satisfaction" {
+		t.Errorf("Expected candidate-only [satisfaction], got %v", comparison.CandidateOnly)
+	}
+}
+
+func TestCompareOutcomes_Regressed(t *testing.T) {
+	job := &Job{
+		ID:       "job",
+		Name:     "Job",
+		Outcomes: []*Outcome{{Type: OutcomeTypeQuality, Metric: "accuracy", Direction: "maximize"}},
+	}
+
+	comparison := CompareOutcomes(job, job,
+		map[string]float64{"accuracy": 0.9},
+		map[string]float64{"accuracy": 0.8},
+	)
+
+	if comparison.Verdict != VerdictRegressed {
+		t.Errorf("Expected verdict %s, got %s", VerdictRegressed, comparison.Verdict)
+	}
+}
+
+func TestCompareJobsAcrossCompanies(t *testing.T) {
+	amazon := &Job{
+		ID:         "amazon-gift",
+		Name:       "Find a Gift",
+		Functional: "Find a thoughtful gift for a family member quickly",
+		Company:    "amazon",
+		Outcomes:   []*Outcome{{Type: OutcomeTypeSpeed, Metric: "time_to_gift", Target: 300}},
+	}
+	walmart := &Job{
+		ID:         "walmart-gift",
+		Name:       "Gift Shopping",
+		Functional: "Find a thoughtful gift for a relative quickly",
+		Company:    "walmart",
+		Outcomes:   []*Outcome{{Type: OutcomeTypeSpeed, Metric: "time_to_gift", Target: 600}},
+	}
+	cvs := &Job{
+		ID:         "cvs-refill",
+		Name:       "Refill Prescription",
+		Functional: "Refill my prescription without waiting in line",
+		Company:    "cvs",
+	}
+
+	comparison := CompareJobsAcrossCompanies([]*Job{amazon, walmart, cvs})
+
+	if len(comparison.Groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(comparison.Groups))
+	}
+
+	gifts := comparison.Groups[0]
+	if len(gifts.Jobs) != 2 {
+		t.Fatalf("Expected gift-finding jobs to group together, got %d jobs", len(gifts.Jobs))
+	}
+	targets := gifts.Targets["time_to_gift"]
+	if targets["amazon"] != 300 || targets["walmart"] != 600 {
+		t.Errorf("Expected speed targets amazon=300 walmart=600, got %v", targets)
+	}
+
+	if len(comparison.Groups[1].Jobs) != 1 || comparison.Groups[1].Jobs[0].ID != "cvs-refill" {
+		t.Errorf("Expected unmatched job in its own group, got %v", comparison.Groups[1].Jobs)
+	}
+}
@@ -4,6 +4,7 @@ package jtbd
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"time"
 )
 
@@ -189,16 +190,73 @@ type DataFactory struct {
 }
 
 func NewDataFactory() *DataFactory {
+	return NewDataFactoryWithSeed(time.Now().UnixNano())
+}
+
+// NewDataFactoryWithSeed creates a DataFactory whose random transactions are
+// reproducible for a given seed.
+func NewDataFactoryWithSeed(seed int64) *DataFactory {
 	df := &DataFactory{
 		personas: make(map[string]*Persona),
 		products: make(map[Fortune5Company]map[string]*Product),
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:     rand.New(rand.NewSource(seed)),
 	}
 	df.initializePersonas()
 	df.initializeProducts()
 	return df
 }
 
+// personaAffinity scores how naturally a persona fits a company. Segment
+// weights dominate; behavior weights break ties between similar segments.
+type personaAffinity struct {
+	segments  map[CustomerSegment]float64
+	behaviors map[BehaviorType]float64
+}
+
+// companyPersonaAffinity is the single table of persona-to-company fit rules.
+var companyPersonaAffinity = map[Fortune5Company]personaAffinity{
+	Walmart: {
+		segments:  map[CustomerSegment]float64{BudgetConscious: 3, Family: 2},
+		behaviors: map[BehaviorType]float64{WeeklyGrocery: 0.5, DealHunter: 0.5},
+	},
+	Amazon: {
+		segments:  map[CustomerSegment]float64{ConvenienceFirst: 3, TechSavvy: 2, PremiumSeeker: 1},
+		behaviors: map[BehaviorType]float64{SubscriptionUser: 0.5, ImpulsePurchase: 0.5},
+	},
+	Apple: {
+		segments:  map[CustomerSegment]float64{TechSavvy: 3, PremiumSeeker: 2},
+		behaviors: map[BehaviorType]float64{ResearchIntensive: 0.5, BrandLoyal: 0.5},
+	},
+	CVS: {
+		segments:  map[CustomerSegment]float64{Elderly: 3, HealthFocused: 2},
+		behaviors: map[BehaviorType]float64{SubscriptionUser: 0.5},
+	},
+	UnitedHealth: {
+		segments:  map[CustomerSegment]float64{Family: 3, Elderly: 2, HealthFocused: 2},
+		behaviors: map[BehaviorType]float64{ResearchIntensive: 0.5},
+	},
+}
+
+// BestPersonaFor returns the persona that best fits the company according to
+// companyPersonaAffinity. Ties are broken by persona ID; it returns nil if the
+// factory has no personas.
+func (df *DataFactory) BestPersonaFor(company Fortune5Company) *Persona {
+	affinity := companyPersonaAffinity[company]
+
+	var best *Persona
+	bestScore := -1.0
+	for _, persona := range df.personas {
+		score := affinity.segments[persona.Segment]
+		for _, behavior := range persona.Behaviors {
+			score += affinity.behaviors[behavior.Type]
+		}
+		if score > bestScore || (score == bestScore && persona.ID < best.ID) {
+			best, bestScore = persona, score
+		}
+	}
+	return best
+}
+
 func (df *DataFactory) initializePersonas() {
 	df.personas["sarah_budget"] = &Persona{
 		ID: "sarah_budget", Name: "Sarah Martinez", Age: 28, Income: 42000, FamilySize: 1,
@@ -380,7 +438,7 @@ func (sb *ScenarioBuilder) Build() map[string]interface{} {
 func (df *DataFactory) GetWalmartGroceryScenario(personaID string) map[string]interface{} {
 	persona := df.personas[personaID]
 	if persona == nil {
-		persona = df.personas["sarah_budget"]
+		persona = df.BestPersonaFor(Walmart)
 	}
 	return NewScenarioBuilder().WithPersona(persona).WithTimeContext(Weekend).
 		WithLocationContext(LocationContext{Type: Suburban, Distance: 2.5}).WithBudget(100.00).
@@ -390,7 +448,7 @@ func (df *DataFactory) GetWalmartGroceryScenario(personaID string) map[string]in
 func (df *DataFactory) GetAmazonPrimeScenario(personaID string) map[string]interface{} {
 	persona := df.personas[personaID]
 	if persona == nil {
-		persona = df.personas["tyler_techsavvy"]
+		persona = df.BestPersonaFor(Amazon)
 	}
 	return NewScenarioBuilder().WithPersona(persona).WithTimeContext(LateNight).WithBudget(500.00).
 		WithProducts(df.products[Amazon]["AMZ-ELEC-001"]).Build()
@@ -399,7 +457,7 @@ func (df *DataFactory) GetAmazonPrimeScenario(personaID string) map[string]inter
 func (df *DataFactory) GetAppleEcosystemScenario(personaID string) map[string]interface{} {
 	persona := df.personas[personaID]
 	if persona == nil {
-		persona = df.personas["patricia_premium"]
+		persona = df.BestPersonaFor(Apple)
 	}
 	return NewScenarioBuilder().WithPersona(persona).WithEventContext(EventContext{Type: "product_launch", Urgency: "high"}).
 		WithBudget(2000.00).WithProducts(df.products[Apple]["AAPL-IP-001"]).Build()
@@ -408,7 +466,7 @@ func (df *DataFactory) GetAppleEcosystemScenario(personaID string) map[string]in
 func (df *DataFactory) GetCVSPharmacyScenario(personaID string) map[string]interface{} {
 	persona := df.personas[personaID]
 	if persona == nil {
-		persona = df.personas["edward_elderly"]
+		persona = df.BestPersonaFor(CVS)
 	}
 	return NewScenarioBuilder().WithPersona(persona).WithEventContext(EventContext{Type: "prescription_refill"}).
 		WithBudget(150.00).WithProducts(df.products[CVS]["CVS-RX-001"]).Build()
@@ -417,7 +475,7 @@ func (df *DataFactory) GetCVSPharmacyScenario(personaID string) map[string]inter
 func (df *DataFactory) GetUnitedHealthEnrollmentScenario(personaID string) map[string]interface{} {
 	persona := df.personas[personaID]
 	if persona == nil {
-		persona = df.personas["fatima_family"]
+		persona = df.BestPersonaFor(UnitedHealth)
 	}
 	return NewScenarioBuilder().WithPersona(persona).WithTimeContext(HolidaySeason).
 		WithEventContext(EventContext{Type: "open_enrollment", Urgency: "high"}).WithBudget(2500.00).
@@ -471,6 +529,8 @@ func (df *DataFactory) GenerateRandomTransaction(personaID string, company Fortu
 	for _, p := range companyProducts {
 		productList = append(productList, p)
 	}
+	// Map order is random; sort so a seeded factory picks the same products
+	sort.Slice(productList, func(i, j int) bool { return productList[i].ID < productList[j].ID })
 
 	for i := 0; i < itemCount && i < len(productList); i++ {
 		idx := df.rand.Intn(len(productList))
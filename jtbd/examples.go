@@ -3,6 +3,7 @@ package jtbd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -96,6 +97,119 @@ func (sjt *SimpleJobTest) Validate() error {
 	return nil
 }
 
+// CompositeJobTest runs several JobTests as one logical test
+type CompositeJobTest struct {
+	name        string
+	description string
+	subs        []JobTest
+}
+
+// NewCompositeJobTest creates a JobTest that passes only if every sub-test
+// passes. Its score is the average of the sub-test scores.
+func NewCompositeJobTest(name, description string, subs ...JobTest) JobTest {
+	return &CompositeJobTest{
+		name:        name,
+		description: description,
+		subs:        subs,
+	}
+}
+
+// Execute implements JobTest. Every sub-test runs even if an earlier one
+// fails or errors; errors are collected into the result rather than returned,
+// and count as a failed sub-test with a score of 0. Outcome results and
+// progress measurements are merged, prefixed with the sub-test name when two
+// sub-tests report the same key. The individual results are kept in
+// Metadata["sub_results"].
+func (cjt *CompositeJobTest) Execute(ctx context.Context, job *Job) (*TestResult, error) {
+	result := &TestResult{
+		TestName:             cjt.name,
+		Success:              true,
+		ProgressMeasurements: make(map[string]float64),
+		OutcomeResults:       make(map[string]*OutcomeResult),
+		Metadata:             make(map[string]interface{}),
+	}
+	if job != nil {
+		result.JobID = job.ID
+	}
+
+	var messages, subErrors []string
+	subResults := make([]*TestResult, 0, len(cjt.subs))
+	totalScore := 0.0
+
+	for _, sub := range cjt.subs {
+		subName := sub.GetTestName()
+		subResult, err := sub.Execute(ctx, job)
+		if err == nil && subResult == nil {
+			err = fmt.Errorf("returned no result")
+		}
+		if err != nil {
+			result.Success = false
+			subErrors = append(subErrors, fmt.Sprintf("%s: %v", subName, err))
+			messages = append(messages, fmt.Sprintf("%s: error: %v", subName, err))
+			continue
+		}
+
+		subResults = append(subResults, subResult)
+		totalScore += subResult.Score
+		if !subResult.Success {
+			result.Success = false
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", subName, subResult.Message))
+
+		for key, value := range subResult.ProgressMeasurements {
+			if _, taken := result.ProgressMeasurements[key]; taken {
+				key = subName + "." + key
+			}
+			result.ProgressMeasurements[key] = value
+		}
+		for key, outcome := range subResult.OutcomeResults {
+			if _, taken := result.OutcomeResults[key]; taken {
+				key = subName + "." + key
+			}
+			result.OutcomeResults[key] = outcome
+		}
+	}
+
+	if len(cjt.subs) > 0 {
+		result.Score = totalScore / float64(len(cjt.subs))
+	}
+	result.Message = strings.Join(messages, "; ")
+	result.Metadata["sub_results"] = subResults
+	if len(subErrors) > 0 {
+		result.Metadata["sub_errors"] = subErrors
+	}
+	return result, nil
+}
+
+// GetTestName implements JobTest
+func (cjt *CompositeJobTest) GetTestName() string {
+	return cjt.name
+}
+
+// GetDescription implements JobTest
+func (cjt *CompositeJobTest) GetDescription() string {
+	return cjt.description
+}
+
+// Validate implements JobTest
+func (cjt *CompositeJobTest) Validate() error {
+	if cjt.name == "" {
+		return NewJTBDError(ErrCodeInvalidTest, "test name cannot be empty", nil)
+	}
+	if len(cjt.subs) == 0 {
+		return NewJTBDError(ErrCodeInvalidTest, "composite test needs at least one sub-test", nil)
+	}
+	for i, sub := range cjt.subs {
+		if sub == nil {
+			return NewJTBDError(ErrCodeInvalidTest, fmt.Sprintf("sub-test %d is nil", i), nil)
+		}
+		if err := sub.Validate(); err != nil {
+			return NewJTBDError(ErrCodeInvalidTest, fmt.Sprintf("sub-test %q is invalid", sub.GetTestName()), err)
+		}
+	}
+	return nil
+}
+
 // ExampleWalmartPantryStocking demonstrates a complete JTBD definition for Walmart
 func ExampleWalmartPantryStocking() (*Job, error) {
 	// Build the job using the fluent builder API
@@ -385,17 +499,7 @@ func ExampleBasicTestExecution() error {
 				// Simulate measurement (in real usage, this would call actual measurement logic)
 				actualValue := outcome.Target * 0.95 // Assume 95% of target achieved
 
-				outcomeResult := &OutcomeResult{
-					OutcomeDescription: outcome.Description,
-					MetricName:         outcome.Metric,
-					ActualValue:        actualValue,
-					TargetValue:        outcome.Target,
-					ThresholdValue:     outcome.Threshold,
-					Unit:               outcome.Unit,
-					MetThreshold:       true,
-					MetTarget:          false,
-					PerformanceRatio:   actualValue / outcome.Target,
-				}
+				outcomeResult := ScoreOutcome(outcome, actualValue)
 
 				result.OutcomeResults[outcome.Metric] = outcomeResult
 
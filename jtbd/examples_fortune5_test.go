@@ -234,7 +234,7 @@ func TestParallelFortune5Execution(t *testing.T) {
 					registry := NewJobRegistry()
 					registry.RegisterJob(jobCopy)
 					executor := NewTestExecutor(registry)
-					_, err := executor.ExecuteTest(ctx, jobCopy.ID, jobCopy.ID)
+					_, err := executor.ExecuteJobAgainstAllTests(ctx, jobCopy.ID)
 					return err
 				},
 				Timeout:    30 * time.Second,
@@ -254,6 +254,42 @@ func TestDataFactory(t *testing.T) {
 	t.Logf("✓ Data factory created with %d Walmart products", len(products))
 }
 
+// TestDataFactory_BestPersonaFor tests persona-to-company affinity.
+func TestDataFactory_BestPersonaFor(t *testing.T) {
+	factory := NewDataFactory()
+
+	apple := factory.BestPersonaFor(Apple)
+	if apple == nil || apple.Segment != TechSavvy {
+		t.Errorf("Expected a TechSavvy persona for Apple, got %+v", apple)
+	}
+
+	cvs := factory.BestPersonaFor(CVS)
+	if cvs == nil || (cvs.Segment != Elderly && cvs.Segment != HealthFocused) {
+		t.Errorf("Expected an Elderly or HealthFocused persona for CVS, got %+v", cvs)
+	}
+
+	scenario := factory.GetAppleEcosystemScenario("unknown")
+	if scenario["persona"] != apple {
+		t.Error("Expected Apple scenario to fall back to the best-fit persona")
+	}
+}
+
+// TestDataFactory_Seeded tests that a seeded factory is reproducible.
+func TestDataFactory_Seeded(t *testing.T) {
+	picks := func(seed int64) []string {
+		txn := NewDataFactoryWithSeed(seed).GenerateRandomTransaction("sarah_budget", Walmart, 3)
+		var ids []string
+		for _, p := range txn.Products {
+			ids = append(ids, fmt.Sprintf("%s x%d", p.Product.ID, p.Quantity))
+		}
+		return ids
+	}
+
+	if a, b := picks(42), picks(42); fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("Expected the same seed to pick the same products, got %v and %v", a, b)
+	}
+}
+
 // TestTestCaseGenerator tests the test case generator functionality.
 func TestTestCaseGenerator(t *testing.T) {
 	gen := NewTestCaseGenerator()
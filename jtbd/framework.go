@@ -33,6 +33,9 @@ package jtbd
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -71,6 +74,34 @@ type JobTest interface {
 	Validate() error
 }
 
+// JobTestFunc is the signature of JobTest.Execute, used by executor middleware
+type JobTestFunc func(ctx context.Context, job *Job) (*TestResult, error)
+
+// Middleware wraps a JobTestFunc to add cross-cutting behavior such as timing,
+// logging or panic recovery
+type Middleware func(next JobTestFunc) JobTestFunc
+
+// RecoveryMiddleware converts a panic inside a test into a failed TestResult
+// instead of crashing the executor
+func RecoveryMiddleware(next JobTestFunc) JobTestFunc {
+	return func(ctx context.Context, job *Job) (result *TestResult, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = &TestResult{
+					Success: false,
+					Score:   0.0,
+					Message: fmt.Sprintf("test panicked: %v", r),
+				}
+				if job != nil {
+					result.JobID = job.ID
+				}
+				err = nil
+			}
+		}()
+		return next(ctx, job)
+	}
+}
+
 // JobDimension represents the three dimensions of a job according to JTBD theory
 type JobDimension string
 
@@ -136,6 +167,40 @@ const (
 	CircumstanceTypeSocial CircumstanceType = "social"
 )
 
+// JobStatus represents where a job definition is in its lifecycle
+type JobStatus string
+
+const (
+	// JobStatusDraft is a job that is still being defined and not yet in use
+	JobStatusDraft JobStatus = "draft"
+
+	// JobStatusActive is a job that is live and being tested against
+	JobStatusActive JobStatus = "active"
+
+	// JobStatusDeprecated is a job that has been retired as the product evolved
+	JobStatusDeprecated JobStatus = "deprecated"
+)
+
+// jobStatusTransitions lists the legal lifecycle transitions for a job
+var jobStatusTransitions = map[JobStatus][]JobStatus{
+	JobStatusDraft:      {JobStatusActive, JobStatusDeprecated},
+	JobStatusActive:     {JobStatusDeprecated},
+	JobStatusDeprecated: {JobStatusActive},
+}
+
+// CanTransitionTo reports whether a job may move from this status to next
+func (s JobStatus) CanTransitionTo(next JobStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range jobStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // Job represents a complete Jobs-to-be-Done definition including all three dimensions
 // (functional, emotional, social), the circumstances in which it arises, and the
 // desired outcomes.
@@ -189,6 +254,10 @@ type Job struct {
 	// Company is the specific company context (walmart, amazon, apple, etc.)
 	Company string
 
+	// Status is the lifecycle status of this job (draft, active, deprecated).
+	// Jobs registered without a status are treated as active.
+	Status JobStatus
+
 	// Metadata contains additional custom properties
 	Metadata map[string]interface{}
 
@@ -238,6 +307,47 @@ type Circumstance struct {
 	Metadata map[string]interface{}
 }
 
+// ConstraintFloat returns the numeric constraint stored under key as a float64.
+// Any integer or float type is accepted.
+func (c *Circumstance) ConstraintFloat(key string) (float64, bool) {
+	if c == nil || c.Constraints == nil {
+		return 0, false
+	}
+	v, ok := c.Constraints[key]
+	if !ok {
+		return 0, false
+	}
+	return toFloat64(v)
+}
+
+// ConstraintInt returns the numeric constraint stored under key as an int.
+// Floats are accepted only when they hold a whole number.
+func (c *Circumstance) ConstraintInt(key string) (int, bool) {
+	f, ok := c.ConstraintFloat(key)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// ConstraintString returns the string constraint stored under key.
+func (c *Circumstance) ConstraintString(key string) (string, bool) {
+	if c == nil || c.Constraints == nil {
+		return "", false
+	}
+	s, ok := c.Constraints[key].(string)
+	return s, ok
+}
+
+// ConstraintBool returns the boolean constraint stored under key.
+func (c *Circumstance) ConstraintBool(key string) (bool, bool) {
+	if c == nil || c.Constraints == nil {
+		return false, false
+	}
+	b, ok := c.Constraints[key].(bool)
+	return b, ok
+}
+
 // Outcome represents a desired result that indicates job completion. JTBD theory
 // emphasizes that customers "hire" products to achieve specific outcomes, not features.
 //
@@ -280,10 +390,71 @@ type Outcome struct {
 	// If actual value doesn't meet threshold, the job is not considered complete
 	Threshold float64
 
+	// Weight is this outcome's relative importance in ScoreOutcomes
+	// Zero means unset, in which case a weight is derived from Priority
+	Weight float64
+
+	// DependsOn lists metrics of outcomes that must meet their threshold for
+	// this outcome to apply, e.g. quality only counts if the job completed
+	DependsOn []string
+
 	// Metadata contains additional custom properties
 	Metadata map[string]interface{}
 }
 
+// validateOutcomeDependencies checks that every DependsOn entry names another
+// outcome's metric and that the dependencies contain no cycle
+func validateOutcomeDependencies(outcomes []*Outcome) error {
+	deps := make(map[string][]string)
+	for _, outcome := range outcomes {
+		if outcome != nil && outcome.Metric != "" {
+			deps[outcome.Metric] = outcome.DependsOn
+		}
+	}
+
+	for metric, prerequisites := range deps {
+		for _, prerequisite := range prerequisites {
+			if _, ok := deps[prerequisite]; !ok {
+				return NewJTBDError(ErrCodeInvalidJob,
+					fmt.Sprintf("outcome %q depends on unknown outcome %q", metric, prerequisite), nil)
+			}
+		}
+	}
+
+	// 0 = unvisited, 1 = on the current path, 2 = done
+	state := make(map[string]int, len(deps))
+	var visit func(metric string) error
+	visit = func(metric string) error {
+		switch state[metric] {
+		case 1:
+			return NewJTBDError(ErrCodeInvalidJob,
+				fmt.Sprintf("outcome dependency cycle through %q", metric), nil)
+		case 2:
+			return nil
+		}
+		state[metric] = 1
+		for _, prerequisite := range deps[metric] {
+			if err := visit(prerequisite); err != nil {
+				return err
+			}
+		}
+		state[metric] = 2
+		return nil
+	}
+
+	metrics := make([]string, 0, len(deps))
+	for metric := range deps {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	for _, metric := range metrics {
+		if err := visit(metric); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // JobRegistry manages a collection of job definitions and provides concurrent-safe
 // access to job data. This is the central repository for all JTBD definitions
 // in the testing framework.
@@ -292,6 +463,56 @@ type JobRegistry struct {
 	jobs        map[string]*Job
 	jobsByIndustry map[string][]*Job
 	jobsByCompany  map[string][]*Job
+	observers      []func(event RegistryEvent)
+	clock          Clock
+}
+
+// RegistryEventType identifies what changed in a JobRegistry
+type RegistryEventType string
+
+const (
+	// RegistryEventAdded fires when a new job ID is registered
+	RegistryEventAdded RegistryEventType = "added"
+
+	// RegistryEventUpdated fires when an existing job is replaced or modified
+	RegistryEventUpdated RegistryEventType = "updated"
+
+	// RegistryEventRemoved fires when a job is removed
+	RegistryEventRemoved RegistryEventType = "removed"
+)
+
+// RegistryEvent describes a change to a JobRegistry
+type RegistryEvent struct {
+	Type  RegistryEventType
+	JobID string
+	Job   *Job
+}
+
+// OnChange registers fn to be called after every successful change to the
+// registry. Observers run in registration order, outside the registry lock,
+// so they may safely call back into the registry.
+func (jr *JobRegistry) OnChange(fn func(event RegistryEvent)) {
+	if fn == nil {
+		return
+	}
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	jr.observers = append(jr.observers, fn)
+}
+
+// notifyAfterUnlock is deferred before taking the write lock so that it runs
+// after the lock is released. It fires *event if the change succeeded.
+func (jr *JobRegistry) notifyAfterUnlock(event **RegistryEvent) {
+	if *event == nil {
+		return
+	}
+	jr.mu.RLock()
+	observers := append([]func(RegistryEvent){}, jr.observers...)
+	jr.mu.RUnlock()
+
+	for _, fn := range observers {
+		fn(**event)
+	}
 }
 
 // NewJobRegistry creates a new JobRegistry instance
@@ -300,7 +521,27 @@ func NewJobRegistry() *JobRegistry {
 		jobs:           make(map[string]*Job),
 		jobsByIndustry: make(map[string][]*Job),
 		jobsByCompany:  make(map[string][]*Job),
+		clock:          realClock{},
+	}
+}
+
+// SetClock replaces the clock used to timestamp jobs and find stale ones
+func (jr *JobRegistry) SetClock(clock Clock) {
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	if clock == nil {
+		clock = realClock{}
+	}
+	jr.clock = clock
+}
+
+// now returns the current time from the registry's clock. A registry built
+// without NewJobRegistry falls back to the system clock.
+func (jr *JobRegistry) now() time.Time {
+	if jr.clock == nil {
+		return time.Now()
 	}
+	return jr.clock.Now()
 }
 
 // RegisterJob adds a job to the registry
@@ -314,12 +555,24 @@ func (jr *JobRegistry) RegisterJob(job *Job) error {
 	if job.Name == "" {
 		return NewJTBDError(ErrCodeInvalidJob, "job name cannot be empty", nil)
 	}
+	if err := validateOutcomeDependencies(job.Outcomes); err != nil {
+		return err
+	}
 
+	var event *RegistryEvent
+	defer jr.notifyAfterUnlock(&event)
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
 
+	// Re-registering replaces the job, so drop its old index entries first
+	eventType := RegistryEventAdded
+	if old, exists := jr.jobs[job.ID]; exists {
+		eventType = RegistryEventUpdated
+		jr.unindexLocked(old)
+	}
+
 	// Set timestamps
-	now := time.Now()
+	now := jr.now()
 	if job.CreatedAt.IsZero() {
 		job.CreatedAt = now
 	}
@@ -330,20 +583,91 @@ func (jr *JobRegistry) RegisterJob(job *Job) error {
 		job.Metadata = make(map[string]interface{})
 	}
 
+	// Jobs without an explicit status are considered live
+	if job.Status == "" {
+		job.Status = JobStatusActive
+	}
+
 	// Store in main registry
 	jr.jobs[job.ID] = job
+	jr.indexLocked(job)
 
-	// Index by industry
+	event = &RegistryEvent{Type: eventType, JobID: job.ID, Job: job}
+	return nil
+}
+
+// UpdateJob replaces a registered job, moving it between the industry and
+// company indexes if those changed. CreatedAt and Status carry over from
+// the stored job when unset on the new one.
+func (jr *JobRegistry) UpdateJob(job *Job) error {
+	if job == nil {
+		return NewJTBDError(ErrCodeInvalidJob, "job cannot be nil", nil)
+	}
+	if job.ID == "" {
+		return NewJTBDError(ErrCodeInvalidJob, "job ID cannot be empty", nil)
+	}
+	if job.Name == "" {
+		return NewJTBDError(ErrCodeInvalidJob, "job name cannot be empty", nil)
+	}
+	if err := validateOutcomeDependencies(job.Outcomes); err != nil {
+		return err
+	}
+
+	var event *RegistryEvent
+	defer jr.notifyAfterUnlock(&event)
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+
+	old, exists := jr.jobs[job.ID]
+	if !exists {
+		return NewJTBDError(ErrCodeJobNotFound, fmt.Sprintf("job %q not found", job.ID), nil)
+	}
+	jr.unindexLocked(old)
+
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = old.CreatedAt
+	}
+	job.UpdatedAt = jr.now()
+	if job.Metadata == nil {
+		job.Metadata = make(map[string]interface{})
+	}
+	if job.Status == "" {
+		job.Status = old.Status
+	}
+
+	jr.jobs[job.ID] = job
+	jr.indexLocked(job)
+
+	event = &RegistryEvent{Type: RegistryEventUpdated, JobID: job.ID, Job: job}
+	return nil
+}
+
+// indexLocked adds job to the industry and company indexes. The caller
+// holds jr.mu.
+func (jr *JobRegistry) indexLocked(job *Job) {
 	if job.Industry != "" {
 		jr.jobsByIndustry[job.Industry] = append(jr.jobsByIndustry[job.Industry], job)
 	}
-
-	// Index by company
 	if job.Company != "" {
 		jr.jobsByCompany[job.Company] = append(jr.jobsByCompany[job.Company], job)
 	}
+}
 
-	return nil
+// unindexLocked removes job from the industry and company indexes it was
+// stored under. The caller holds jr.mu.
+func (jr *JobRegistry) unindexLocked(job *Job) {
+	if job.Industry != "" {
+		jr.jobsByIndustry[job.Industry] = jr.removeFromSlice(jr.jobsByIndustry[job.Industry], job.ID)
+		if len(jr.jobsByIndustry[job.Industry]) == 0 {
+			delete(jr.jobsByIndustry, job.Industry)
+		}
+	}
+	if job.Company != "" {
+		jr.jobsByCompany[job.Company] = jr.removeFromSlice(jr.jobsByCompany[job.Company], job.ID)
+		if len(jr.jobsByCompany[job.Company]) == 0 {
+			delete(jr.jobsByCompany, job.Company)
+		}
+	}
 }
 
 // GetJob retrieves a job by ID
@@ -394,8 +718,110 @@ func (jr *JobRegistry) ListJobsByCompany(company string) []*Job {
 	return jobs
 }
 
-// RemoveJob removes a job from the registry
-func (jr *JobRegistry) RemoveJob(id string) error {
+// ListJobsByStatus returns all jobs with a specific lifecycle status
+func (jr *JobRegistry) ListJobsByStatus(status JobStatus) []*Job {
+	jr.mu.RLock()
+	defer jr.mu.RUnlock()
+
+	jobs := make([]*Job, 0)
+	for _, job := range jr.jobs {
+		if job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// Search returns jobs whose name, description or functional, emotional or
+// social dimension contains query, ignoring case. Jobs matching more fields
+// rank first; ties are ordered by ID.
+func (jr *JobRegistry) Search(query string) []*Job {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return []*Job{}
+	}
+
+	jr.mu.RLock()
+	defer jr.mu.RUnlock()
+
+	type match struct {
+		job   *Job
+		score int
+	}
+	matches := make([]match, 0)
+	for _, job := range jr.jobs {
+		score := 0
+		for _, field := range []string{job.Name, job.Description, job.Functional, job.Emotional, job.Social} {
+			if strings.Contains(strings.ToLower(field), q) {
+				score++
+			}
+		}
+		if score > 0 {
+			matches = append(matches, match{job: job, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, k int) bool {
+		if matches[i].score != matches[k].score {
+			return matches[i].score > matches[k].score
+		}
+		return matches[i].job.ID < matches[k].job.ID
+	})
+
+	jobs := make([]*Job, len(matches))
+	for i, m := range matches {
+		jobs[i] = m.job
+	}
+	return jobs
+}
+
+// StaleJobs returns jobs not updated within olderThan, oldest first, so
+// product teams can review whether they are still relevant
+func (jr *JobRegistry) StaleJobs(olderThan time.Duration) []*Job {
+	jr.mu.RLock()
+	defer jr.mu.RUnlock()
+
+	cutoff := jr.now().Add(-olderThan)
+
+	jobs := make([]*Job, 0)
+	for _, job := range jr.jobs {
+		if job.UpdatedAt.Before(cutoff) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, k int) bool {
+		if !jobs[i].UpdatedAt.Equal(jobs[k].UpdatedAt) {
+			return jobs[i].UpdatedAt.Before(jobs[k].UpdatedAt)
+		}
+		return jobs[i].ID < jobs[k].ID
+	})
+	return jobs
+}
+
+// EvaluateAll evaluates every registered job's outcomes against its entry in
+// measured, which is keyed by job ID, in a single locked pass. Jobs without
+// an entry get an empty result map.
+func (jr *JobRegistry) EvaluateAll(measured map[string]map[string]float64) map[string]map[string]*OutcomeResult {
+	jr.mu.RLock()
+	defer jr.mu.RUnlock()
+
+	results := make(map[string]map[string]*OutcomeResult, len(jr.jobs))
+	for id, job := range jr.jobs {
+		results[id] = job.EvaluateOutcomes(measured[id])
+	}
+	return results
+}
+
+// SetJobStatus moves a job to a new lifecycle status, rejecting illegal
+// transitions such as deprecated back to draft
+func (jr *JobRegistry) SetJobStatus(id string, status JobStatus) error {
+	if _, known := jobStatusTransitions[status]; !known {
+		return NewJTBDError(ErrCodeInvalidInput, fmt.Sprintf("unknown job status %q", status), nil)
+	}
+
+	var event *RegistryEvent
+	defer jr.notifyAfterUnlock(&event)
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
 
@@ -404,18 +830,33 @@ func (jr *JobRegistry) RemoveJob(id string) error {
 		return NewJTBDError(ErrCodeJobNotFound, fmt.Sprintf("job %q not found", id), nil)
 	}
 
-	delete(jr.jobs, id)
-
-	// Remove from industry index
-	if job.Industry != "" {
-		jr.removeFromSlice(jr.jobsByIndustry[job.Industry], id)
+	if !job.Status.CanTransitionTo(status) {
+		return NewJTBDError(ErrCodeInvalidTransition,
+			fmt.Sprintf("job %q cannot transition from %s to %s", id, job.Status, status), nil)
 	}
 
-	// Remove from company index
-	if job.Company != "" {
-		jr.removeFromSlice(jr.jobsByCompany[job.Company], id)
+	job.Status = status
+	job.UpdatedAt = jr.now()
+	event = &RegistryEvent{Type: RegistryEventUpdated, JobID: id, Job: job}
+	return nil
+}
+
+// RemoveJob removes a job from the registry
+func (jr *JobRegistry) RemoveJob(id string) error {
+	var event *RegistryEvent
+	defer jr.notifyAfterUnlock(&event)
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+
+	job, exists := jr.jobs[id]
+	if !exists {
+		return NewJTBDError(ErrCodeJobNotFound, fmt.Sprintf("job %q not found", id), nil)
 	}
 
+	delete(jr.jobs, id)
+	jr.unindexLocked(job)
+
+	event = &RegistryEvent{Type: RegistryEventRemoved, JobID: id, Job: job}
 	return nil
 }
 
@@ -490,6 +931,288 @@ type OutcomeResult struct {
 
 	// PerformanceRatio is ActualValue / TargetValue (adjusted for direction)
 	PerformanceRatio float64
+
+	// NotApplicable is set when a prerequisite outcome (see Outcome.DependsOn)
+	// missed its threshold. MetTarget and MetThreshold still hold the raw
+	// comparison, but the outcome should be neither passed nor failed.
+	NotApplicable bool
+}
+
+// EvaluateOutcomes measures each outcome against the matching value in
+// measured, keyed by outcome metric. Outcomes without a measurement are
+// omitted from the result.
+func (j *Job) EvaluateOutcomes(measured map[string]float64) map[string]*OutcomeResult {
+	return j.EvaluateOutcomesWithAliases(measured, nil)
+}
+
+// EvaluateOutcomesWithAliases is like EvaluateOutcomes, but aliases maps an
+// outcome metric name to the key used for it in measured. This lets a job
+// say "completion_time" while telemetry reports "duration_ms".
+func (j *Job) EvaluateOutcomesWithAliases(measured map[string]float64, aliases map[string]string) map[string]*OutcomeResult {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	results := make(map[string]*OutcomeResult, len(j.Outcomes))
+	for _, outcome := range j.Outcomes {
+		if outcome == nil || outcome.Metric == "" {
+			continue
+		}
+
+		key := outcome.Metric
+		if alias, ok := aliases[outcome.Metric]; ok {
+			key = alias
+		}
+		actual, ok := measured[key]
+		if !ok {
+			continue
+		}
+
+		results[outcome.Metric] = ScoreOutcome(outcome, actual)
+	}
+
+	markNotApplicable(j.Outcomes, results)
+	return results
+}
+
+// markNotApplicable flags results whose prerequisites missed their threshold
+// or are themselves not applicable. Unmeasured prerequisites don't count.
+func markNotApplicable(outcomes []*Outcome, results map[string]*OutcomeResult) {
+	deps := make(map[string][]string)
+	for _, outcome := range outcomes {
+		if outcome != nil && len(outcome.DependsOn) > 0 {
+			deps[outcome.Metric] = outcome.DependsOn
+		}
+	}
+
+	resolved := make(map[string]bool)
+	var resolve func(metric string)
+	resolve = func(metric string) {
+		if resolved[metric] {
+			return
+		}
+		resolved[metric] = true // also guards against cycles in unvalidated jobs
+		result, ok := results[metric]
+		if !ok {
+			return
+		}
+		for _, prerequisite := range deps[metric] {
+			resolve(prerequisite)
+			if pre, ok := results[prerequisite]; ok && (!pre.MetThreshold || pre.NotApplicable) {
+				result.NotApplicable = true
+			}
+		}
+	}
+	for metric := range deps {
+		resolve(metric)
+	}
+}
+
+// ScoreOutcomes evaluates the measured values and returns a weighted score
+// from 0.0 to 1.0. Each outcome scores 1.0 when it meets its target and its
+// direction-adjusted performance ratio otherwise. Outcomes are weighted by
+// Weight, or by 1/Priority when Weight is unset. Unmeasured and
+// not-applicable outcomes are left out of the score.
+func (j *Job) ScoreOutcomes(measured map[string]float64) (float64, error) {
+	results := j.EvaluateOutcomes(measured)
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	var weighted, totalWeight float64
+	for _, outcome := range j.Outcomes {
+		if outcome == nil {
+			continue
+		}
+		if outcome.Weight < 0 {
+			return 0, NewJTBDError(ErrCodeInvalidInput,
+				fmt.Sprintf("outcome %q has negative weight %.2f", outcome.Metric, outcome.Weight), nil)
+		}
+
+		result, ok := results[outcome.Metric]
+		if !ok || result.NotApplicable {
+			continue
+		}
+
+		weight := outcomeWeight(outcome)
+		score := 1.0
+		if !result.MetTarget {
+			score = math.Max(0, math.Min(1, result.PerformanceRatio))
+		}
+		weighted += weight * score
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return 0, nil
+	}
+	return weighted / totalWeight, nil
+}
+
+// Evaluate scores every outcome against measurements, keyed by metric, and
+// returns the overall fulfillment as a TestResult. Score is the weighted
+// ScoreOutcomes score; Success requires every priority-1 outcome that is
+// applicable to meet its threshold. Every outcome metric must be measured.
+func (j *Job) Evaluate(measurements map[string]float64) (*TestResult, error) {
+	j.mu.RLock()
+	var missing []string
+	var critical []string
+	for _, outcome := range j.Outcomes {
+		if outcome == nil || outcome.Metric == "" {
+			continue
+		}
+		if _, ok := measurements[outcome.Metric]; !ok {
+			missing = append(missing, outcome.Metric)
+		}
+		if outcome.Priority == 1 {
+			critical = append(critical, outcome.Metric)
+		}
+	}
+	jobID := j.ID
+	j.mu.RUnlock()
+
+	if len(missing) > 0 {
+		return nil, NewJTBDError(ErrCodeInvalidInput,
+			fmt.Sprintf("job %q has no measurement for %s", jobID, strings.Join(missing, ", ")), nil)
+	}
+
+	score, err := j.ScoreOutcomes(measurements)
+	if err != nil {
+		return nil, err
+	}
+	results := j.EvaluateOutcomes(measurements)
+
+	var missed []string
+	for _, metric := range critical {
+		if result := results[metric]; !result.MetThreshold && !result.NotApplicable {
+			missed = append(missed, metric)
+		}
+	}
+	sort.Strings(missed)
+
+	result := &TestResult{
+		TestName:             "evaluate",
+		JobID:                jobID,
+		Success:              len(missed) == 0,
+		Score:                score,
+		ProgressMeasurements: make(map[string]float64),
+		OutcomeResults:       results,
+		Timestamp:            time.Now(),
+		Metadata:             make(map[string]interface{}),
+	}
+	if result.Success {
+		result.Message = "All priority 1 outcomes met their thresholds"
+	} else {
+		result.Message = fmt.Sprintf("Priority 1 outcomes missed their thresholds: %s", strings.Join(missed, ", "))
+	}
+	return result, nil
+}
+
+// outcomeWeight returns the outcome's explicit weight, or one derived from
+// its priority (1 = highest) when no weight is set
+func outcomeWeight(outcome *Outcome) float64 {
+	if outcome.Weight > 0 {
+		return outcome.Weight
+	}
+	if outcome.Priority > 0 {
+		return 1.0 / float64(outcome.Priority)
+	}
+	return 1.0
+}
+
+// ScoreOutcome compares an actual value against an outcome's target and
+// threshold, honouring its direction. Only a Direction of "minimize" makes
+// lower values better; an unset or unrecognised Direction is maximized.
+// PerformanceRatio is target/actual when minimizing and actual/target when
+// maximizing; where that would divide by zero it is 1 if the target was met
+// and 0 if not.
+func ScoreOutcome(outcome *Outcome, actual float64) *OutcomeResult {
+	if outcome == nil {
+		return nil
+	}
+
+	result := &OutcomeResult{
+		OutcomeDescription: outcome.Description,
+		MetricName:         outcome.Metric,
+		ActualValue:        actual,
+		TargetValue:        outcome.Target,
+		ThresholdValue:     outcome.Threshold,
+		Unit:               outcome.Unit,
+	}
+
+	var numerator, denominator float64
+	if outcome.Direction == "minimize" {
+		result.MetTarget = actual <= outcome.Target
+		result.MetThreshold = actual <= outcome.Threshold
+		numerator, denominator = outcome.Target, actual
+	} else {
+		result.MetTarget = actual >= outcome.Target
+		result.MetThreshold = actual >= outcome.Threshold
+		numerator, denominator = actual, outcome.Target
+	}
+
+	switch {
+	case denominator != 0:
+		result.PerformanceRatio = numerator / denominator
+	case result.MetTarget:
+		result.PerformanceRatio = 1
+	}
+
+	return result
+}
+
+// ProgressMeasurement is a single progress indicator reading
+type ProgressMeasurement struct {
+	Name  string
+	Type  IndicatorType
+	Value float64
+}
+
+// JobProgress holds the readings gathered by MeasureJobProgress
+type JobProgress struct {
+	// Measurements are in indicator order
+	Measurements []ProgressMeasurement
+
+	// Truncated is set when ctx expired before every indicator was measured
+	Truncated bool
+}
+
+// MeasureJobProgress measures the job's indicators in order, checking ctx
+// between them. If ctx expires, the measurements gathered so far are returned
+// with Truncated set rather than an error; an indicator that fails because
+// ctx expired mid-measurement is dropped. Any other indicator error stops
+// measurement and is returned alongside the partial progress.
+func (j *Job) MeasureJobProgress(ctx context.Context) (*JobProgress, error) {
+	j.mu.RLock()
+	indicators := append([]ProgressIndicator(nil), j.Indicators...)
+	j.mu.RUnlock()
+
+	progress := &JobProgress{Measurements: make([]ProgressMeasurement, 0, len(indicators))}
+	for _, indicator := range indicators {
+		if indicator == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			progress.Truncated = true
+			return progress, nil
+		}
+
+		value, err := indicator.Measure(ctx, j)
+		if err != nil {
+			if ctx.Err() != nil {
+				progress.Truncated = true
+				return progress, nil
+			}
+			return progress, NewJTBDError(ErrCodeInternalError,
+				fmt.Sprintf("indicator %q failed", indicator.GetName()), err)
+		}
+
+		progress.Measurements = append(progress.Measurements, ProgressMeasurement{
+			Name:  indicator.GetName(),
+			Type:  indicator.GetType(),
+			Value: value,
+		})
+	}
+	return progress, nil
 }
 
 // TestExecutor manages the execution of job tests
@@ -498,6 +1221,9 @@ type TestExecutor struct {
 	registry *JobRegistry
 	tests    map[string]JobTest
 	results  []*TestResult
+	clock    Clock
+	tracker  *ProgressTracker
+	chain    []Middleware
 }
 
 // NewTestExecutor creates a new TestExecutor instance
@@ -506,7 +1232,39 @@ func NewTestExecutor(registry *JobRegistry) *TestExecutor {
 		registry: registry,
 		tests:    make(map[string]JobTest),
 		results:  make([]*TestResult, 0),
+		clock:    realClock{},
+	}
+}
+
+// Use adds middleware around every test's Execute. Middleware runs in
+// registration order: the first registered is the outermost wrapper.
+func (te *TestExecutor) Use(middleware Middleware) {
+	if middleware == nil {
+		return
+	}
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.chain = append(te.chain, middleware)
+}
+
+// SetProgressTracker makes the executor copy the numeric values of the
+// tracker's latest snapshot into each result's ProgressMeasurements, as long
+// as the snapshot was recorded while that test ran. Values the test set
+// itself are kept. Pass nil to stop copying.
+func (te *TestExecutor) SetProgressTracker(tracker *ProgressTracker) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.tracker = tracker
+}
+
+// SetClock replaces the clock used to timestamp test results
+func (te *TestExecutor) SetClock(clock Clock) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if clock == nil {
+		clock = realClock{}
 	}
+	te.clock = clock
 }
 
 // RegisterTest adds a test to the executor
@@ -530,25 +1288,76 @@ func (te *TestExecutor) RegisterTest(test JobTest) error {
 func (te *TestExecutor) ExecuteTest(ctx context.Context, testName string, jobID string) (*TestResult, error) {
 	te.mu.RLock()
 	test, exists := te.tests[testName]
+	clock := te.clock
+	tracker := te.tracker
+	chain := te.chain
 	te.mu.RUnlock()
 
 	if !exists {
+		// A job ID passed as the test name is an easy mistake; say so
+		if _, jobErr := te.registry.GetJob(testName); jobErr == nil {
+			return nil, NewJTBDError(ErrCodeTestNotFound, fmt.Sprintf(
+				"test %q not found: %q is a job ID, not a test name; use ExecuteJobAgainstAllTests to run every test against a job",
+				testName, testName), nil)
+		}
 		return nil, NewJTBDError(ErrCodeTestNotFound, fmt.Sprintf("test %q not found", testName), nil)
 	}
 
 	job, err := te.registry.GetJob(jobID)
 	if err != nil {
+		te.mu.RLock()
+		_, isTest := te.tests[jobID]
+		te.mu.RUnlock()
+		if isTest {
+			return nil, NewJTBDError(ErrCodeJobNotFound, fmt.Sprintf(
+				"job %q not found: %q is a test name, not a job ID; ExecuteTest takes (testName, jobID)",
+				jobID, jobID), err)
+		}
 		return nil, err
 	}
 
-	startTime := time.Now()
-	result, err := test.Execute(ctx, job)
+	execute := JobTestFunc(test.Execute)
+	for i := len(chain) - 1; i >= 0; i-- {
+		execute = chain[i](execute)
+	}
+
+	// The tracker stamps snapshots with the wall clock, not te.clock
+	trackedSince := time.Now()
+	startTime := clock.Now()
+	result, err := execute(ctx, job)
 	if err != nil {
 		return nil, err
 	}
+	if result.TestName == "" {
+		result.TestName = testName
+	}
 
-	result.ExecutionTime = time.Since(startTime)
-	result.Timestamp = time.Now()
+	result.Timestamp = clock.Now()
+	result.ExecutionTime = result.Timestamp.Sub(startTime)
+
+	if tracker != nil {
+		if snapshot, ok := tracker.latestSnapshot(trackedSince); ok {
+			if result.ProgressMeasurements == nil {
+				result.ProgressMeasurements = make(map[string]float64)
+			}
+			for key, value := range snapshot.Values {
+				if _, set := result.ProgressMeasurements[key]; set {
+					continue
+				}
+				if num, ok := toFloat64(value); ok {
+					result.ProgressMeasurements[key] = num
+				}
+			}
+		}
+	}
+
+	// Warn when testing a job that has been retired
+	if job.Status == JobStatusDeprecated {
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]interface{})
+		}
+		result.Metadata["warning"] = fmt.Sprintf("job %q is deprecated", job.ID)
+	}
 
 	// Store result
 	te.mu.Lock()
@@ -579,6 +1388,12 @@ func (te *TestExecutor) ExecuteAllTests(ctx context.Context, jobID string) ([]*T
 	return results, nil
 }
 
+// ExecuteJobAgainstAllTests runs every registered test against a job. It is
+// ExecuteAllTests under a name that makes clear the argument is a job ID.
+func (te *TestExecutor) ExecuteJobAgainstAllTests(ctx context.Context, jobID string) ([]*TestResult, error) {
+	return te.ExecuteAllTests(ctx, jobID)
+}
+
 // GetResults returns all test results
 func (te *TestExecutor) GetResults() []*TestResult {
 	te.mu.RLock()
@@ -598,15 +1413,42 @@ func (te *TestExecutor) ClearResults() {
 	te.results = make([]*TestResult, 0)
 }
 
+// Default caps applied by NewJobBuilder to keep generated jobs manageable
+const (
+	DefaultMaxCircumstances = 10
+	DefaultMaxOutcomes      = 20
+)
+
+// JobLimits caps how many circumstances and outcomes a JobBuilder accepts.
+// Zero values fall back to the defaults.
+type JobLimits struct {
+	MaxCircumstances int
+	MaxOutcomes      int
+}
+
 // JobBuilder provides a fluent API for constructing Job definitions
 type JobBuilder struct {
-	job *Job
-	err error
+	job    *Job
+	err    error
+	limits JobLimits
 }
 
-// NewJobBuilder creates a new JobBuilder instance
+// NewJobBuilder creates a new JobBuilder instance with the default limits
 func NewJobBuilder(id, name string) *JobBuilder {
+	return NewJobBuilderWithLimits(id, name, JobLimits{})
+}
+
+// NewJobBuilderWithLimits creates a JobBuilder that rejects jobs with more
+// circumstances or outcomes than limits allows
+func NewJobBuilderWithLimits(id, name string, limits JobLimits) *JobBuilder {
+	if limits.MaxCircumstances <= 0 {
+		limits.MaxCircumstances = DefaultMaxCircumstances
+	}
+	if limits.MaxOutcomes <= 0 {
+		limits.MaxOutcomes = DefaultMaxOutcomes
+	}
 	return &JobBuilder{
+		limits: limits,
 		job: &Job{
 			ID:            id,
 			Name:          name,
@@ -618,6 +1460,95 @@ func NewJobBuilder(id, name string) *JobBuilder {
 	}
 }
 
+// NewJobBuilderFrom creates a JobBuilder seeded with a deep copy of job, so
+// a variant (another company, tweaked outcomes) can be derived without
+// touching the original. The copy gets the ID "<job.ID>-copy", which WithID
+// can override. Status and timestamps are cleared so the clone is registered
+// as a new job. Indicators are shared, as they are behaviour, not data.
+func NewJobBuilderFrom(job *Job) *JobBuilder {
+	if job == nil {
+		return &JobBuilder{
+			job: &Job{Metadata: make(map[string]interface{})},
+			err: NewJTBDError(ErrCodeInvalidJob, "job cannot be nil", nil),
+		}
+	}
+
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+
+	jb := NewJobBuilder(job.ID+"-copy", job.Name)
+	jb.job.Description = job.Description
+	jb.job.Functional = job.Functional
+	jb.job.Emotional = job.Emotional
+	jb.job.Social = job.Social
+	jb.job.Industry = job.Industry
+	jb.job.Company = job.Company
+	jb.job.Metadata = copyMetadata(job.Metadata)
+	if jb.job.Metadata == nil {
+		jb.job.Metadata = make(map[string]interface{})
+	}
+	jb.job.Indicators = append(jb.job.Indicators, job.Indicators...)
+	for _, circumstance := range job.Circumstances {
+		jb.job.Circumstances = append(jb.job.Circumstances, cloneCircumstance(circumstance))
+	}
+	for _, outcome := range job.Outcomes {
+		jb.job.Outcomes = append(jb.job.Outcomes, cloneOutcome(outcome))
+	}
+
+	// Cloning an oversized job shouldn't fail; later additions still can
+	if n := len(jb.job.Circumstances); n > jb.limits.MaxCircumstances {
+		jb.limits.MaxCircumstances = n
+	}
+	if n := len(jb.job.Outcomes); n > jb.limits.MaxOutcomes {
+		jb.limits.MaxOutcomes = n
+	}
+	return jb
+}
+
+// cloneCircumstance deep-copies a circumstance's maps and triggers
+func cloneCircumstance(c *Circumstance) *Circumstance {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	clone.Constraints = copyMetadata(c.Constraints)
+	clone.Metadata = copyMetadata(c.Metadata)
+	clone.Triggers = append([]string(nil), c.Triggers...)
+	return &clone
+}
+
+// cloneOutcome deep-copies an outcome's dependencies and metadata
+func cloneOutcome(o *Outcome) *Outcome {
+	if o == nil {
+		return nil
+	}
+	clone := *o
+	clone.DependsOn = append([]string(nil), o.DependsOn...)
+	clone.Metadata = copyMetadata(o.Metadata)
+	return &clone
+}
+
+// copyMetadata returns a shallow copy of m, or nil when m is nil
+func copyMetadata(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// WithID sets the job ID
+func (jb *JobBuilder) WithID(id string) *JobBuilder {
+	if jb.err != nil {
+		return jb
+	}
+	jb.job.ID = id
+	return jb
+}
+
 // WithDescription sets the job description
 func (jb *JobBuilder) WithDescription(description string) *JobBuilder {
 	if jb.err != nil {
@@ -677,6 +1608,11 @@ func (jb *JobBuilder) AddCircumstance(circumstance *Circumstance) *JobBuilder {
 	if jb.err != nil {
 		return jb
 	}
+	if len(jb.job.Circumstances) >= jb.limits.MaxCircumstances {
+		jb.err = NewJTBDError(ErrCodeInvalidJob,
+			fmt.Sprintf("job exceeds the limit of %d circumstances", jb.limits.MaxCircumstances), nil)
+		return jb
+	}
 	jb.job.Circumstances = append(jb.job.Circumstances, circumstance)
 	return jb
 }
@@ -686,6 +1622,11 @@ func (jb *JobBuilder) AddOutcome(outcome *Outcome) *JobBuilder {
 	if jb.err != nil {
 		return jb
 	}
+	if len(jb.job.Outcomes) >= jb.limits.MaxOutcomes {
+		jb.err = NewJTBDError(ErrCodeInvalidJob,
+			fmt.Sprintf("job exceeds the limit of %d outcomes", jb.limits.MaxOutcomes), nil)
+		return jb
+	}
 	jb.job.Outcomes = append(jb.job.Outcomes, outcome)
 	return jb
 }
@@ -725,6 +1666,87 @@ func (jb *JobBuilder) Build() (*Job, error) {
 	return jb.job, nil
 }
 
+// BuildAll validates the job like Build but reports every violation at once
+// instead of stopping at the first. It checks required fields, outcome metrics,
+// duplicate metrics and circumstance intensity. The job is nil if any check fails.
+func (jb *JobBuilder) BuildAll() (*Job, []error) {
+	var errs []error
+	if jb.err != nil {
+		errs = append(errs, jb.err)
+	}
+	errs = append(errs, validateJobFields(jb.job)...)
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return jb.job, nil
+}
+
+// ValidateJobCompleteness reports everything that keeps an already-built job
+// from being a complete job statement: the BuildAll checks plus a missing
+// functional dimension. It returns nil for a complete job.
+func ValidateJobCompleteness(job *Job) []error {
+	if job == nil {
+		return []error{NewJTBDError(ErrCodeInvalidJob, "job cannot be nil", nil)}
+	}
+
+	errs := validateJobFields(job)
+	if strings.TrimSpace(job.Functional) == "" {
+		errs = append(errs, NewJTBDError(ErrCodeInvalidJob, "functional dimension is required", nil))
+	}
+	return errs
+}
+
+// validateJobFields holds the rules shared by BuildAll and
+// ValidateJobCompleteness: required ID and name, outcome metrics, weights,
+// duplicates and dependencies, and circumstance intensity
+func validateJobFields(job *Job) []error {
+	var errs []error
+	if job.ID == "" {
+		errs = append(errs, NewJTBDError(ErrCodeInvalidJob, "job ID is required", nil))
+	}
+	if job.Name == "" {
+		errs = append(errs, NewJTBDError(ErrCodeInvalidJob, "job name is required", nil))
+	}
+
+	seenMetrics := make(map[string]bool)
+	for i, outcome := range job.Outcomes {
+		if outcome == nil {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob, fmt.Sprintf("outcome %d is nil", i), nil))
+			continue
+		}
+		if outcome.Metric == "" {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob, fmt.Sprintf("outcome %d has no metric", i), nil))
+			continue
+		}
+		if outcome.Weight < 0 {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob,
+				fmt.Sprintf("outcome %d has negative weight %.2f", i, outcome.Weight), nil))
+		}
+		if seenMetrics[outcome.Metric] {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob,
+				fmt.Sprintf("outcome %d duplicates metric %q", i, outcome.Metric), nil))
+		}
+		seenMetrics[outcome.Metric] = true
+	}
+
+	if err := validateOutcomeDependencies(job.Outcomes); err != nil {
+		errs = append(errs, err)
+	}
+
+	for i, circumstance := range job.Circumstances {
+		if circumstance == nil {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob, fmt.Sprintf("circumstance %d is nil", i), nil))
+			continue
+		}
+		if circumstance.Intensity < 0 || circumstance.Intensity > 1 {
+			errs = append(errs, NewJTBDError(ErrCodeInvalidJob,
+				fmt.Sprintf("circumstance %d intensity %.2f is outside [0, 1]", i, circumstance.Intensity), nil))
+		}
+	}
+	return errs
+}
+
 // JTBDError represents errors specific to the JTBD framework
 type JTBDError struct {
 	Code    string
@@ -763,4 +1785,6 @@ const (
 	ErrCodeTestFailed    = "test_failed"
 	ErrCodeInvalidInput  = "invalid_input"
 	ErrCodeInternalError = "internal_error"
+
+	ErrCodeInvalidTransition = "invalid_transition"
 )
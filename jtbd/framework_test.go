@@ -3,6 +3,8 @@ package jtbd
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -148,6 +150,41 @@ func TestJobRegistry_ListJobsByIndustry(t *testing.T) {
 	}
 }
 
+func TestJobRegistry_Search(t *testing.T) {
+	registry := NewJobRegistry()
+
+	for _, build := range []func() (*Job, error){
+		ExampleWalmartPantryStocking,
+		ExampleAmazonGiftFinding,
+		ExampleAppleFamilyConnection,
+	} {
+		job, err := build()
+		if err != nil {
+			t.Fatalf("Failed to build job: %v", err)
+		}
+		if err := registry.RegisterJob(job); err != nil {
+			t.Fatalf("Failed to register job: %v", err)
+		}
+	}
+
+	results := registry.Search("FAMILY")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 jobs mentioning family, got %d", len(results))
+	}
+
+	// Apple mentions family in its name too, so it ranks first
+	if results[0].ID != "apple-family-connection" {
+		t.Errorf("Expected apple-family-connection first, got %s", results[0].ID)
+	}
+	if results[1].ID != "walmart-monthly-pantry-stock" {
+		t.Errorf("Expected walmart-monthly-pantry-stock second, got %s", results[1].ID)
+	}
+
+	if results := registry.Search("   "); len(results) != 0 {
+		t.Errorf("Expected no results for empty query, got %d", len(results))
+	}
+}
+
 func TestJobRegistry_RemoveJob(t *testing.T) {
 	registry := NewJobRegistry()
 
@@ -236,6 +273,213 @@ func TestJobBuilder_MissingRequiredFields(t *testing.T) {
 	}
 }
 
+func TestJobBuilder_OutcomeLimit(t *testing.T) {
+	_, err := NewJobBuilderWithLimits("capped", "Capped Job", JobLimits{MaxOutcomes: 2}).
+		AddOutcome(&Outcome{Type: OutcomeTypeSpeed, Metric: "time"}).
+		AddOutcome(&Outcome{Type: OutcomeTypeCost, Metric: "cost"}).
+		AddOutcome(&Outcome{Type: OutcomeTypeQuality, Metric: "accuracy"}).
+		Build()
+	if err == nil {
+		t.Fatal("Expected error when adding a third outcome past the cap, got nil")
+	}
+
+	job, err := NewJobBuilderWithLimits("capped", "Capped Job", JobLimits{MaxOutcomes: 2}).
+		AddOutcome(&Outcome{Type: OutcomeTypeSpeed, Metric: "time"}).
+		AddOutcome(&Outcome{Type: OutcomeTypeCost, Metric: "cost"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Expected job within the cap to build, got %v", err)
+	}
+	if len(job.Outcomes) != 2 {
+		t.Errorf("Expected 2 outcomes, got %d", len(job.Outcomes))
+	}
+}
+
+func TestNewJobBuilderFrom_DeepCopy(t *testing.T) {
+	source, err := NewJobBuilder("walmart-grocery", "Weekly groceries").
+		WithFunctional("Restock the pantry").
+		WithCompany("Walmart").
+		AddCircumstance(&Circumstance{
+			Type:        CircumstanceTypeTemporal,
+			Constraints: map[string]interface{}{"budget_limit": 150.0},
+			Triggers:    []string{"weekend"},
+		}).
+		AddOutcome(&Outcome{
+			Metric:   "checkout_time",
+			Target:   60,
+			Metadata: map[string]interface{}{"source": "pos"},
+		}).
+		WithMetadata("region", "us").
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build source job: %v", err)
+	}
+	source.CreatedAt = time.Now().Add(-time.Hour)
+	source.UpdatedAt = source.CreatedAt
+
+	clone, err := NewJobBuilderFrom(source).WithCompany("Target").Build()
+	if err != nil {
+		t.Fatalf("Failed to build clone: %v", err)
+	}
+	if clone.ID != "walmart-grocery-copy" || clone.Functional != source.Functional {
+		t.Errorf("Unexpected clone identity: %s / %q", clone.ID, clone.Functional)
+	}
+	if !clone.CreatedAt.IsZero() || !clone.UpdatedAt.IsZero() {
+		t.Error("Expected clone timestamps to be reset")
+	}
+
+	clone.Outcomes[0].Target = 30
+	clone.Outcomes[0].Metadata["source"] = "web"
+	clone.Circumstances[0].Constraints["budget_limit"] = 99.0
+	clone.Circumstances[0].Triggers[0] = "payday"
+	clone.Metadata["region"] = "eu"
+
+	if source.Outcomes[0].Target != 60 || source.Outcomes[0].Metadata["source"] != "pos" {
+		t.Errorf("Source outcome changed: %+v", source.Outcomes[0])
+	}
+	if source.Circumstances[0].Constraints["budget_limit"] != 150.0 || source.Circumstances[0].Triggers[0] != "weekend" {
+		t.Errorf("Source circumstance changed: %+v", source.Circumstances[0])
+	}
+	if source.Metadata["region"] != "us" || source.Company != "Walmart" {
+		t.Errorf("Source job changed: company %s, metadata %v", source.Company, source.Metadata)
+	}
+
+	renamed, err := NewJobBuilderFrom(source).WithID("target-grocery").Build()
+	if err != nil || renamed.ID != "target-grocery" {
+		t.Errorf("Expected WithID to override the clone ID, got %v (err %v)", renamed, err)
+	}
+}
+
+func TestJobBuilder_BuildAll_ReportsEveryViolation(t *testing.T) {
+	job, errs := NewJobBuilder("test-job", "Test Job").
+		AddOutcome(&Outcome{Type: OutcomeTypeSpeed, Metric: ""}).
+		AddCircumstance(&Circumstance{Type: CircumstanceTypeTemporal, Intensity: 1.5}).
+		BuildAll()
+
+	if job != nil {
+		t.Error("Expected nil job when validation fails")
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	for _, err := range errs {
+		jtbdErr, ok := err.(*JTBDError)
+		if !ok {
+			t.Fatalf("Expected JTBDError, got %T", err)
+		}
+		if jtbdErr.Code != ErrCodeInvalidJob {
+			t.Errorf("Expected error code %s, got %s", ErrCodeInvalidJob, jtbdErr.Code)
+		}
+	}
+
+	// A valid builder returns the job and no errors
+	job, errs = NewJobBuilder("ok", "OK").
+		AddOutcome(&Outcome{Type: OutcomeTypeSpeed, Metric: "time"}).
+		BuildAll()
+	if job == nil || len(errs) != 0 {
+		t.Errorf("Expected valid job with no errors, got %v, %v", job, errs)
+	}
+}
+
+func TestValidateJobCompleteness_MatchesBuildAll(t *testing.T) {
+	builder := NewJobBuilder("invalid-job", "Invalid Job").
+		WithFunctional("Check out groceries").
+		AddOutcome(&Outcome{Type: OutcomeTypeSpeed, Metric: ""}).
+		AddOutcome(&Outcome{Type: OutcomeTypeCost, Metric: "spend", Weight: -1}).
+		AddOutcome(&Outcome{Type: OutcomeTypeCost, Metric: "spend"}).
+		AddCircumstance(&Circumstance{Type: CircumstanceTypeTemporal, Intensity: 1.5})
+
+	_, buildErrs := builder.BuildAll()
+	completenessErrs := ValidateJobCompleteness(builder.job)
+
+	if len(buildErrs) != 4 {
+		t.Fatalf("Expected 4 BuildAll violations, got %d: %v", len(buildErrs), buildErrs)
+	}
+	if len(completenessErrs) != len(buildErrs) {
+		t.Fatalf("Expected the same violations, got BuildAll %v and ValidateJobCompleteness %v", buildErrs, completenessErrs)
+	}
+	for i := range buildErrs {
+		if buildErrs[i].Error() != completenessErrs[i].Error() {
+			t.Errorf("Violation %d differs: BuildAll %q, ValidateJobCompleteness %q", i, buildErrs[i], completenessErrs[i])
+		}
+	}
+}
+
+func TestJob_EvaluateOutcomesWithAliases(t *testing.T) {
+	job := &Job{
+		ID:   "aliased",
+		Name: "Aliased Job",
+		Outcomes: []*Outcome{
+			{
+				Type:      OutcomeTypeSpeed,
+				Metric:    "completion_time",
+				Target:    200,
+				Threshold: 500,
+				Direction: "minimize",
+			},
+		},
+	}
+
+	measured := map[string]float64{"duration_ms": 300}
+
+	// Without an alias the metric can't be found
+	if results := job.EvaluateOutcomes(measured); len(results) != 0 {
+		t.Errorf("Expected no results without alias, got %d", len(results))
+	}
+
+	results := job.EvaluateOutcomesWithAliases(measured, map[string]string{"completion_time": "duration_ms"})
+	result, ok := results["completion_time"]
+	if !ok {
+		t.Fatal("Expected a result for completion_time")
+	}
+	if result.ActualValue != 300 {
+		t.Errorf("Expected actual value 300, got %.2f", result.ActualValue)
+	}
+	if !result.MetThreshold {
+		t.Error("Expected 300 to meet minimize threshold of 500")
+	}
+	if result.MetTarget {
+		t.Error("Expected 300 to miss minimize target of 200")
+	}
+}
+
+func TestJob_ScoreOutcomes_Weights(t *testing.T) {
+	newJob := func(costWeight, speedWeight float64) *Job {
+		return &Job{
+			ID:   "weighted",
+			Name: "Weighted Job",
+			Outcomes: []*Outcome{
+				{Type: OutcomeTypeCost, Metric: "cost", Target: 50, Direction: "minimize", Priority: 1, Weight: costWeight},
+				{Type: OutcomeTypeSpeed, Metric: "time", Target: 60, Direction: "minimize", Priority: 1, Weight: speedWeight},
+			},
+		}
+	}
+
+	// Cost misses at double its target, speed meets its target
+	measured := map[string]float64{"cost": 100, "time": 30}
+
+	equal, err := newJob(0, 0).ScoreOutcomes(measured)
+	if err != nil {
+		t.Fatalf("ScoreOutcomes failed: %v", err)
+	}
+	weighted, err := newJob(2.0, 1.0).ScoreOutcomes(measured)
+	if err != nil {
+		t.Fatalf("ScoreOutcomes failed: %v", err)
+	}
+
+	if equal != 0.75 {
+		t.Errorf("Expected equal-priority score 0.75, got %.4f", equal)
+	}
+	if weighted >= equal {
+		t.Errorf("Expected heavier cost miss to lower the score below %.4f, got %.4f", equal, weighted)
+	}
+
+	if _, err := newJob(-1, 1).ScoreOutcomes(measured); err == nil {
+		t.Error("Expected error for negative weight, got nil")
+	}
+}
+
 func TestTestExecutor_RegisterTest(t *testing.T) {
 	registry := NewJobRegistry()
 	executor := NewTestExecutor(registry)
@@ -555,6 +799,761 @@ func TestTestExecutor_ClearResults(t *testing.T) {
 	}
 }
 
+func TestTestExecutor_ExecuteTest_FakeClock(t *testing.T) {
+	registry := NewJobRegistry()
+	executor := NewTestExecutor(registry)
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	executor.SetClock(clock)
+
+	if err := registry.RegisterJob(&Job{ID: "clock-job", Name: "Clock Job"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	test := NewSimpleJobTest("timed", "Advances the clock", func(ctx context.Context, j *Job) (*TestResult, error) {
+		clock.Advance(3 * time.Second)
+		return &TestResult{TestName: "timed", JobID: j.ID, Success: true}, nil
+	})
+	if err := executor.RegisterTest(test); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	result, err := executor.ExecuteTest(context.Background(), "timed", "clock-job")
+	if err != nil {
+		t.Fatalf("Failed to execute test: %v", err)
+	}
+
+	if result.ExecutionTime != 3*time.Second {
+		t.Errorf("Expected execution time of exactly 3s, got %v", result.ExecutionTime)
+	}
+	if !result.Timestamp.Equal(clock.Now()) {
+		t.Errorf("Expected timestamp %v, got %v", clock.Now(), result.Timestamp)
+	}
+}
+
+func TestTestExecutor_ExecuteTest_ProgressTracker(t *testing.T) {
+	registry := NewJobRegistry()
+	executor := NewTestExecutor(registry)
+	tracker := NewProgressTracker()
+	executor.SetProgressTracker(tracker)
+
+	if err := registry.RegisterJob(&Job{ID: "tracked-job", Name: "Tracked Job"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	test := NewSimpleJobTest("tracked", "Records progress", func(ctx context.Context, j *Job) (*TestResult, error) {
+		tracker.RecordProgress("cart", map[string]interface{}{
+			"items_added": 3,
+			"cart_total":  42.5,
+			"store":       "online",
+		})
+		return &TestResult{TestName: "tracked", JobID: j.ID, Success: true}, nil
+	})
+	if err := executor.RegisterTest(test); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	result, err := executor.ExecuteTest(context.Background(), "tracked", "tracked-job")
+	if err != nil {
+		t.Fatalf("Failed to execute test: %v", err)
+	}
+
+	if result.ProgressMeasurements["items_added"] != 3 {
+		t.Errorf("Expected items_added=3, got %v", result.ProgressMeasurements["items_added"])
+	}
+	if result.ProgressMeasurements["cart_total"] != 42.5 {
+		t.Errorf("Expected cart_total=42.5, got %v", result.ProgressMeasurements["cart_total"])
+	}
+	if _, ok := result.ProgressMeasurements["store"]; ok {
+		t.Error("Expected non-numeric values to be skipped")
+	}
+
+	// A later test that records nothing must not inherit the cart snapshot
+	quiet := NewSimpleJobTest("quiet", "Records nothing", func(ctx context.Context, j *Job) (*TestResult, error) {
+		return &TestResult{TestName: "quiet", JobID: j.ID, Success: true}, nil
+	})
+	if err := executor.RegisterTest(quiet); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	result, err = executor.ExecuteTest(context.Background(), "quiet", "tracked-job")
+	if err != nil {
+		t.Fatalf("Failed to execute test: %v", err)
+	}
+	if len(result.ProgressMeasurements) != 0 {
+		t.Errorf("Expected no progress measurements, got %v", result.ProgressMeasurements)
+	}
+}
+
+func TestTestExecutor_RecoveryMiddleware(t *testing.T) {
+	registry := NewJobRegistry()
+	executor := NewTestExecutor(registry)
+	executor.Use(RecoveryMiddleware)
+
+	if err := registry.RegisterJob(&Job{ID: "panic-job", Name: "Panic Job"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	test := NewSimpleJobTest("panicky", "Panics mid-test", func(ctx context.Context, j *Job) (*TestResult, error) {
+		panic("nil map write")
+	})
+	if err := executor.RegisterTest(test); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	result, err := executor.ExecuteTest(context.Background(), "panicky", "panic-job")
+	if err != nil {
+		t.Fatalf("Expected panic to become a failed result, got error: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected panicking test to fail")
+	}
+	if result.TestName != "panicky" || result.JobID != "panic-job" {
+		t.Errorf("Expected result for panicky/panic-job, got %s/%s", result.TestName, result.JobID)
+	}
+}
+
+func TestTestExecutor_MiddlewareOrder(t *testing.T) {
+	registry := NewJobRegistry()
+	executor := NewTestExecutor(registry)
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next JobTestFunc) JobTestFunc {
+			return func(ctx context.Context, job *Job) (*TestResult, error) {
+				order = append(order, name)
+				return next(ctx, job)
+			}
+		}
+	}
+	executor.Use(trace("first"))
+	executor.Use(trace("second"))
+
+	if err := registry.RegisterJob(&Job{ID: "mw-job", Name: "Middleware Job"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	test := NewSimpleJobTest("traced", "Records middleware order", func(ctx context.Context, j *Job) (*TestResult, error) {
+		order = append(order, "test")
+		return &TestResult{Success: true}, nil
+	})
+	if err := executor.RegisterTest(test); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	if _, err := executor.ExecuteTest(context.Background(), "traced", "mw-job"); err != nil {
+		t.Fatalf("Failed to execute test: %v", err)
+	}
+
+	if got := fmt.Sprint(order); got != "[first second test]" {
+		t.Errorf("Expected [first second test], got %s", got)
+	}
+}
+
+func TestCompositeJobTest_AggregatesSubTests(t *testing.T) {
+	passing := NewSimpleJobTest("outcome-met", "Outcome met", func(ctx context.Context, j *Job) (*TestResult, error) {
+		return &TestResult{
+			Success:        true,
+			Score:          1.0,
+			Message:        "target met",
+			OutcomeResults: map[string]*OutcomeResult{"time": {MetricName: "time", MetTarget: true}},
+		}, nil
+	})
+	failing := NewSimpleJobTest("cost-compliant", "Cost compliant", func(ctx context.Context, j *Job) (*TestResult, error) {
+		return &TestResult{
+			Success:              false,
+			Score:                0.4,
+			Message:              "over budget",
+			OutcomeResults:       map[string]*OutcomeResult{"cost": {MetricName: "cost"}},
+			ProgressMeasurements: map[string]float64{"budget_used": 1.3},
+		}, nil
+	})
+
+	composite := NewCompositeJobTest("checkout", "Checkout is fast and cheap", passing, failing)
+	if err := composite.Validate(); err != nil {
+		t.Fatalf("Expected composite to validate, got %v", err)
+	}
+
+	result, err := composite.Execute(context.Background(), &Job{ID: "checkout-job"})
+	if err != nil {
+		t.Fatalf("Composite execute returned error: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected composite to fail when one sub-test fails")
+	}
+	if math.Abs(result.Score-0.7) > 1e-9 {
+		t.Errorf("Expected averaged score 0.7, got %.2f", result.Score)
+	}
+	if result.OutcomeResults["time"] == nil || result.OutcomeResults["cost"] == nil {
+		t.Errorf("Expected outcome results from both sub-tests, got %v", result.OutcomeResults)
+	}
+	if result.ProgressMeasurements["budget_used"] != 1.3 {
+		t.Errorf("Expected merged progress measurements, got %v", result.ProgressMeasurements)
+	}
+	if subs, _ := result.Metadata["sub_results"].([]*TestResult); len(subs) != 2 {
+		t.Errorf("Expected 2 sub-results, got %v", result.Metadata["sub_results"])
+	}
+}
+
+func TestJobRegistry_StaleJobs(t *testing.T) {
+	registry := NewJobRegistry()
+	for _, id := range []string{"fresh", "stale", "recent"} {
+		if err := registry.RegisterJob(&Job{ID: id, Name: id}); err != nil {
+			t.Fatalf("Failed to register job %s: %v", id, err)
+		}
+	}
+
+	stale, _ := registry.GetJob("stale")
+	stale.UpdatedAt = time.Now().Add(-400 * 24 * time.Hour)
+
+	jobs := registry.StaleJobs(30 * 24 * time.Hour)
+	if len(jobs) != 1 || jobs[0].ID != "stale" {
+		ids := make([]string, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ID
+		}
+		t.Errorf("Expected only the backdated job, got %v", ids)
+	}
+}
+
+func TestJobRegistry_SetClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	registry := NewJobRegistry()
+	registry.SetClock(clock)
+
+	if err := registry.RegisterJob(&Job{ID: "old", Name: "Old"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	clock.Advance(60 * 24 * time.Hour)
+	if err := registry.RegisterJob(&Job{ID: "new", Name: "New"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	old, _ := registry.GetJob("old")
+	if !old.CreatedAt.Equal(start) || !old.UpdatedAt.Equal(start) {
+		t.Errorf("Expected timestamps from the fake clock, got created %v updated %v", old.CreatedAt, old.UpdatedAt)
+	}
+
+	jobs := registry.StaleJobs(30 * 24 * time.Hour)
+	if len(jobs) != 1 || jobs[0].ID != "old" {
+		t.Errorf("Expected only the job registered before the advance to be stale, got %v", jobs)
+	}
+
+	if err := registry.SetJobStatus("old", JobStatusDeprecated); err != nil {
+		t.Fatalf("SetJobStatus failed: %v", err)
+	}
+	if !old.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("Expected SetJobStatus to stamp %v, got %v", clock.Now(), old.UpdatedAt)
+	}
+}
+
+func TestJob_EvaluateOutcomes_DependsOn(t *testing.T) {
+	job := &Job{
+		ID:   "checkout",
+		Name: "Checkout",
+		Outcomes: []*Outcome{
+			{Metric: "completion_rate", Target: 0.95, Threshold: 0.9, Direction: "maximize"},
+			{Metric: "quality_score", Target: 4.5, Threshold: 4.0, Direction: "maximize", DependsOn: []string{"completion_rate"}},
+		},
+	}
+	if err := NewJobRegistry().RegisterJob(job); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	results := job.EvaluateOutcomes(map[string]float64{"completion_rate": 0.5, "quality_score": 2.0})
+	if results["completion_rate"].MetThreshold {
+		t.Error("Expected completion to miss its threshold")
+	}
+	if !results["quality_score"].NotApplicable {
+		t.Error("Expected quality to be not applicable when completion fails")
+	}
+
+	results = job.EvaluateOutcomes(map[string]float64{"completion_rate": 0.97, "quality_score": 2.0})
+	if results["quality_score"].NotApplicable {
+		t.Error("Expected quality to apply when completion meets its threshold")
+	}
+
+	// Dependency cycles are rejected
+	job.Outcomes[0].DependsOn = []string{"quality_score"}
+	if err := NewJobRegistry().RegisterJob(job); err == nil {
+		t.Error("Expected error registering a job with an outcome dependency cycle")
+	}
+}
+
+func TestJobRegistry_OnChange(t *testing.T) {
+	registry := NewJobRegistry()
+
+	var events []string
+	registry.OnChange(func(event RegistryEvent) {
+		events = append(events, fmt.Sprintf("%s:%s", event.Type, event.JobID))
+		// Observers run outside the lock, so reading back is safe
+		registry.ListJobs()
+	})
+	var second int
+	registry.OnChange(func(event RegistryEvent) { second++ })
+
+	if err := registry.RegisterJob(&Job{ID: "a", Name: "A"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	if err := registry.SetJobStatus("a", JobStatusDeprecated); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	if err := registry.RemoveJob("a"); err != nil {
+		t.Fatalf("Failed to remove job: %v", err)
+	}
+	if err := registry.RemoveJob("a"); err == nil {
+		t.Fatal("Expected error removing a missing job")
+	}
+
+	if got := fmt.Sprint(events); got != "[added:a updated:a removed:a]" {
+		t.Errorf("Expected [added:a updated:a removed:a], got %s", got)
+	}
+	if second != 3 {
+		t.Errorf("Expected second observer to see 3 events, got %d", second)
+	}
+}
+
+func TestTestExecutor_ExecuteTest_JobIDAsTestName(t *testing.T) {
+	registry := NewJobRegistry()
+	executor := NewTestExecutor(registry)
+	if err := registry.RegisterJob(&Job{ID: "walmart-pantry", Name: "Pantry"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	test := NewSimpleJobTest("pantry-check", "Checks pantry", func(ctx context.Context, j *Job) (*TestResult, error) {
+		return &TestResult{Success: true}, nil
+	})
+	if err := executor.RegisterTest(test); err != nil {
+		t.Fatalf("Failed to register test: %v", err)
+	}
+
+	// Job ID passed in both positions
+	_, err := executor.ExecuteTest(context.Background(), "walmart-pantry", "walmart-pantry")
+	if err == nil || !strings.Contains(err.Error(), "is a job ID, not a test name") {
+		t.Errorf("Expected a diagnostic naming the job ID mix-up, got %v", err)
+	}
+
+	// Arguments swapped
+	_, err = executor.ExecuteTest(context.Background(), "pantry-check", "pantry-check")
+	if err == nil || !strings.Contains(err.Error(), "is a test name, not a job ID") {
+		t.Errorf("Expected a diagnostic naming the test name mix-up, got %v", err)
+	}
+
+	results, err := executor.ExecuteJobAgainstAllTests(context.Background(), "walmart-pantry")
+	if err != nil || len(results) != 1 {
+		t.Errorf("Expected 1 result from ExecuteJobAgainstAllTests, got %d (err %v)", len(results), err)
+	}
+}
+
+func TestJob_MeasureJobProgress_Deadline(t *testing.T) {
+	instant := func(v float64) func(context.Context, *Job) (float64, error) {
+		return func(ctx context.Context, j *Job) (float64, error) { return v, nil }
+	}
+	slow := func(ctx context.Context, j *Job) (float64, error) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Second):
+			return 1.0, nil
+		}
+	}
+
+	job := &Job{ID: "progress-job", Indicators: []ProgressIndicator{
+		NewSimpleProgressIndicator("started", IndicatorTypeLeading, instant(1.0)),
+		NewSimpleProgressIndicator("steps", IndicatorTypeConcurrent, instant(0.5)),
+		NewSimpleProgressIndicator("quality", IndicatorTypeLagging, slow),
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	progress, err := job.MeasureJobProgress(ctx)
+	if err != nil {
+		t.Fatalf("Expected partial results without error, got %v", err)
+	}
+	if !progress.Truncated {
+		t.Error("Expected Truncated to be set")
+	}
+	if len(progress.Measurements) != 2 {
+		t.Fatalf("Expected 2 measurements, got %d", len(progress.Measurements))
+	}
+	if progress.Measurements[0].Name != "started" || progress.Measurements[1].Value != 0.5 {
+		t.Errorf("Unexpected measurements: %+v", progress.Measurements)
+	}
+}
+
+func TestJobRegistry_EvaluateAll(t *testing.T) {
+	registry := NewJobRegistry()
+	outcome := func() []*Outcome {
+		return []*Outcome{{Description: "Fast checkout", Metric: "checkout_time", Target: 30, Threshold: 60, Direction: "minimize"}}
+	}
+	for _, id := range []string{"walmart-checkout", "target-checkout", "costco-checkout"} {
+		if err := registry.RegisterJob(&Job{ID: id, Name: id, Outcomes: outcome()}); err != nil {
+			t.Fatalf("Failed to register %s: %v", id, err)
+		}
+	}
+
+	results := registry.EvaluateAll(map[string]map[string]float64{
+		"walmart-checkout": {"checkout_time": 25},
+		"target-checkout":  {"checkout_time": 90},
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected results for 3 jobs, got %d", len(results))
+	}
+	if r := results["walmart-checkout"]["checkout_time"]; r == nil || r.ActualValue != 25 || !r.MetTarget {
+		t.Errorf("walmart-checkout should reflect only its own measurement, got %+v", r)
+	}
+	if r := results["target-checkout"]["checkout_time"]; r == nil || r.ActualValue != 90 || r.MetThreshold {
+		t.Errorf("target-checkout should reflect only its own measurement, got %+v", r)
+	}
+	if len(results["costco-checkout"]) != 0 {
+		t.Errorf("Expected empty results for unmeasured job, got %v", results["costco-checkout"])
+	}
+}
+
+func TestJobRegistry_UpdateJob_Reindexes(t *testing.T) {
+	registry := NewJobRegistry()
+	if err := registry.RegisterJob(&Job{ID: "onboarding", Name: "Onboarding", Industry: "retail", Company: "Walmart"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	if err := registry.RegisterJob(&Job{ID: "returns", Name: "Returns", Industry: "retail"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	var events []RegistryEvent
+	registry.OnChange(func(e RegistryEvent) { events = append(events, e) })
+
+	if err := registry.UpdateJob(&Job{ID: "onboarding", Name: "Onboarding", Industry: "healthcare", Company: "UnitedHealth"}); err != nil {
+		t.Fatalf("UpdateJob failed: %v", err)
+	}
+
+	retail := registry.ListJobsByIndustry("retail")
+	if len(retail) != 1 || retail[0].ID != "returns" {
+		t.Errorf("Expected only returns under retail, got %d jobs", len(retail))
+	}
+	healthcare := registry.ListJobsByIndustry("healthcare")
+	if len(healthcare) != 1 || healthcare[0].ID != "onboarding" {
+		t.Errorf("Expected onboarding exactly once under healthcare, got %d jobs", len(healthcare))
+	}
+	if len(registry.ListJobsByCompany("Walmart")) != 0 {
+		t.Error("Expected onboarding to be removed from the Walmart index")
+	}
+	if job, _ := registry.GetJob("onboarding"); job.Status != JobStatusActive || job.CreatedAt.IsZero() {
+		t.Errorf("Expected status and CreatedAt to carry over, got %s / %v", job.Status, job.CreatedAt)
+	}
+	if len(events) != 1 || events[0].Type != RegistryEventUpdated {
+		t.Errorf("Expected one updated event, got %+v", events)
+	}
+
+	err := registry.UpdateJob(&Job{ID: "missing", Name: "Missing"})
+	if jtbdErr, ok := err.(*JTBDError); !ok || jtbdErr.Code != ErrCodeJobNotFound {
+		t.Errorf("Expected job_not_found, got %v", err)
+	}
+}
+
+func TestJobRegistry_RegisterJob_ReplacesIndexes(t *testing.T) {
+	registry := NewJobRegistry()
+	if err := registry.RegisterJob(&Job{ID: "onboarding", Name: "Onboarding", Industry: "retail", Company: "Walmart"}); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+	if err := registry.RegisterJob(&Job{ID: "onboarding", Name: "Onboarding", Industry: "healthcare", Company: "UnitedHealth"}); err != nil {
+		t.Fatalf("Failed to re-register job: %v", err)
+	}
+
+	if len(registry.ListJobsByIndustry("retail")) != 0 {
+		t.Error("Expected onboarding to be removed from the retail index")
+	}
+	if len(registry.ListJobsByCompany("Walmart")) != 0 {
+		t.Error("Expected onboarding to be removed from the Walmart index")
+	}
+	if healthcare := registry.ListJobsByIndustry("healthcare"); len(healthcare) != 1 || healthcare[0].ID != "onboarding" {
+		t.Errorf("Expected onboarding exactly once under healthcare, got %d jobs", len(healthcare))
+	}
+	if len(registry.ListJobsByCompany("UnitedHealth")) != 1 {
+		t.Error("Expected onboarding exactly once under UnitedHealth")
+	}
+
+	// Re-registering with unchanged fields must not duplicate the entry
+	if err := registry.RegisterJob(&Job{ID: "onboarding", Name: "Onboarding", Industry: "healthcare", Company: "UnitedHealth"}); err != nil {
+		t.Fatalf("Failed to re-register job: %v", err)
+	}
+	if healthcare := registry.ListJobsByIndustry("healthcare"); len(healthcare) != 1 {
+		t.Errorf("Expected onboarding exactly once under healthcare, got %d jobs", len(healthcare))
+	}
+}
+
+func TestJobRegistry_RemoveJob_UpdatesIndexes(t *testing.T) {
+	registry := NewJobRegistry()
+	for _, id := range []string{"restock", "checkout"} {
+		if err := registry.RegisterJob(&Job{ID: id, Name: id, Industry: "retail", Company: "Walmart"}); err != nil {
+			t.Fatalf("Failed to register %s: %v", id, err)
+		}
+	}
+
+	if err := registry.RemoveJob("restock"); err != nil {
+		t.Fatalf("RemoveJob failed: %v", err)
+	}
+
+	retail := registry.ListJobsByIndustry("retail")
+	if len(retail) != 1 || retail[0].ID != "checkout" {
+		t.Errorf("Expected only checkout under retail, got %d jobs", len(retail))
+	}
+	walmart := registry.ListJobsByCompany("Walmart")
+	if len(walmart) != 1 || walmart[0].ID != "checkout" {
+		t.Errorf("Expected only checkout under Walmart, got %d jobs", len(walmart))
+	}
+}
+
+func TestScoreOutcome(t *testing.T) {
+	tests := []struct {
+		name          string
+		outcome       *Outcome
+		actual        float64
+		wantThreshold bool
+		wantTarget    bool
+		wantRatio     float64
+	}{
+		{
+			name:          "minimize beats target",
+			outcome:       &Outcome{Metric: "shopping_time", Direction: "minimize", Target: 20, Threshold: 30},
+			actual:        10,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     2,
+		},
+		{
+			name:          "minimize meets threshold only",
+			outcome:       &Outcome{Metric: "shopping_time", Direction: "minimize", Target: 20, Threshold: 30},
+			actual:        25,
+			wantThreshold: true,
+			wantRatio:     0.8,
+		},
+		{
+			name:      "minimize misses threshold",
+			outcome:   &Outcome{Metric: "shopping_time", Direction: "minimize", Target: 20, Threshold: 30},
+			actual:    40,
+			wantRatio: 0.5,
+		},
+		{
+			name:          "minimize zero actual",
+			outcome:       &Outcome{Metric: "wait_time", Direction: "minimize", Target: 5, Threshold: 10},
+			actual:        0,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     1,
+		},
+		{
+			name:          "maximize meets threshold only",
+			outcome:       &Outcome{Metric: "satisfaction", Direction: "maximize", Target: 4, Threshold: 3},
+			actual:        3.2,
+			wantThreshold: true,
+			wantRatio:     0.8,
+		},
+		{
+			name:      "maximize misses threshold",
+			outcome:   &Outcome{Metric: "satisfaction", Direction: "maximize", Target: 4, Threshold: 3},
+			actual:    2,
+			wantRatio: 0.5,
+		},
+		{
+			name:          "maximize zero target",
+			outcome:       &Outcome{Metric: "defects_fixed", Direction: "maximize"},
+			actual:        3,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     1,
+		},
+		{
+			name:          "unknown direction maximizes",
+			outcome:       &Outcome{Metric: "nps", Direction: "upwards", Target: 50, Threshold: 30},
+			actual:        60,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     1.2,
+		},
+		{
+			name:          "unset direction maximizes speed",
+			outcome:       &Outcome{Type: OutcomeTypeSpeed, Metric: "checkout_time", Target: 60, Threshold: 40},
+			actual:        75,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     1.25,
+		},
+		{
+			name:          "unknown direction maximizes cost",
+			outcome:       &Outcome{Type: OutcomeTypeCost, Metric: "basket_cost", Direction: "upwards", Target: 50, Threshold: 30},
+			actual:        100,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     2,
+		},
+		{
+			name:          "unset direction maximizes quality",
+			outcome:       &Outcome{Type: OutcomeTypeQuality, Metric: "accuracy", Target: 90, Threshold: 80},
+			actual:        99,
+			wantThreshold: true,
+			wantTarget:    true,
+			wantRatio:     1.1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ScoreOutcome(tt.outcome, tt.actual)
+			if result.MetThreshold != tt.wantThreshold {
+				t.Errorf("MetThreshold = %v, want %v", result.MetThreshold, tt.wantThreshold)
+			}
+			if result.MetTarget != tt.wantTarget {
+				t.Errorf("MetTarget = %v, want %v", result.MetTarget, tt.wantTarget)
+			}
+			if math.Abs(result.PerformanceRatio-tt.wantRatio) > 1e-9 {
+				t.Errorf("PerformanceRatio = %v, want %v", result.PerformanceRatio, tt.wantRatio)
+			}
+		})
+	}
+}
+
+func TestJob_Evaluate_PriorityWeighting(t *testing.T) {
+	job := &Job{
+		ID:   "walmart-checkout",
+		Name: "Checkout",
+		Outcomes: []*Outcome{
+			{Metric: "checkout_time", Direction: "minimize", Target: 60, Threshold: 90, Priority: 1},
+			{Metric: "satisfaction", Direction: "maximize", Target: 4, Threshold: 3, Priority: 3},
+		},
+	}
+
+	// Priority-3 miss only lowers the score
+	result, err := job.Evaluate(map[string]float64{"checkout_time": 45, "satisfaction": 2})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected success when only a priority-3 outcome misses, got %q", result.Message)
+	}
+	// (1*1.0 + 1/3*0.5) / (1 + 1/3)
+	if want := 0.875; math.Abs(result.Score-want) > 1e-9 {
+		t.Errorf("Expected score %.3f, got %.3f", want, result.Score)
+	}
+	if len(result.OutcomeResults) != 2 || result.JobID != job.ID {
+		t.Errorf("Expected 2 outcome results for %s, got %d for %s", job.ID, len(result.OutcomeResults), result.JobID)
+	}
+
+	// Priority-1 miss fails the job
+	result, err = job.Evaluate(map[string]float64{"checkout_time": 120, "satisfaction": 5})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected failure when a priority-1 outcome misses its threshold")
+	}
+	if !strings.Contains(result.Message, "checkout_time") {
+		t.Errorf("Expected message to name checkout_time, got %q", result.Message)
+	}
+	if result.Score <= 0 || result.Score >= 1 {
+		t.Errorf("Expected a partial score, got %.3f", result.Score)
+	}
+
+	if _, err := job.Evaluate(map[string]float64{"checkout_time": 45}); err == nil {
+		t.Error("Expected an error for a missing satisfaction measurement")
+	}
+}
+
+func TestJobRegistry_SetJobStatus(t *testing.T) {
+	registry := NewJobRegistry()
+
+	job := &Job{ID: "lifecycle-job", Name: "Lifecycle Job", Status: JobStatusDraft}
+	if err := registry.RegisterJob(job); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	// Legal transition: draft -> active -> deprecated
+	if err := registry.SetJobStatus("lifecycle-job", JobStatusActive); err != nil {
+		t.Fatalf("Expected draft -> active to succeed: %v", err)
+	}
+	if err := registry.SetJobStatus("lifecycle-job", JobStatusDeprecated); err != nil {
+		t.Fatalf("Expected active -> deprecated to succeed: %v", err)
+	}
+
+	deprecated := registry.ListJobsByStatus(JobStatusDeprecated)
+	if len(deprecated) != 1 || deprecated[0].ID != "lifecycle-job" {
+		t.Errorf("Expected lifecycle-job to be listed as deprecated, got %v", deprecated)
+	}
+
+	// Illegal transition: deprecated -> draft
+	err := registry.SetJobStatus("lifecycle-job", JobStatusDraft)
+	if err == nil {
+		t.Fatal("Expected error transitioning deprecated -> draft, got nil")
+	}
+
+	jtbdErr, ok := err.(*JTBDError)
+	if !ok {
+		t.Fatalf("Expected JTBDError, got %T", err)
+	}
+	if jtbdErr.Code != ErrCodeInvalidTransition {
+		t.Errorf("Expected error code %s, got %s", ErrCodeInvalidTransition, jtbdErr.Code)
+	}
+	if job.Status != JobStatusDeprecated {
+		t.Errorf("Expected status to remain deprecated, got %s", job.Status)
+	}
+}
+
+func TestJobRegistry_RegisterJob_DefaultsToActive(t *testing.T) {
+	registry := NewJobRegistry()
+
+	job := &Job{ID: "default-status", Name: "Default Status"}
+	if err := registry.RegisterJob(job); err != nil {
+		t.Fatalf("Failed to register job: %v", err)
+	}
+
+	if job.Status != JobStatusActive {
+		t.Errorf("Expected default status %s, got %s", JobStatusActive, job.Status)
+	}
+}
+
+func TestCircumstance_ConstraintAccessors(t *testing.T) {
+	circ := &Circumstance{
+		Type: CircumstanceTypeSituational,
+		Constraints: map[string]interface{}{
+			"budget_limit": 100,
+			"location":     "store",
+			"urgent":       true,
+			"max_items":    3.0,
+			"partial":      2.5,
+		},
+	}
+
+	// Float stored as int
+	if v, ok := circ.ConstraintFloat("budget_limit"); !ok || v != 100.0 {
+		t.Errorf("Expected (100, true), got (%v, %v)", v, ok)
+	}
+
+	// Missing key
+	if v, ok := circ.ConstraintFloat("missing"); ok || v != 0 {
+		t.Errorf("Expected (0, false) for missing key, got (%v, %v)", v, ok)
+	}
+
+	// Wrong type
+	if v, ok := circ.ConstraintFloat("location"); ok || v != 0 {
+		t.Errorf("Expected (0, false) for string value, got (%v, %v)", v, ok)
+	}
+	if v, ok := circ.ConstraintString("budget_limit"); ok || v != "" {
+		t.Errorf("Expected (\"\", false) for numeric value, got (%q, %v)", v, ok)
+	}
+
+	if v, ok := circ.ConstraintString("location"); !ok || v != "store" {
+		t.Errorf("Expected (store, true), got (%q, %v)", v, ok)
+	}
+	if v, ok := circ.ConstraintBool("urgent"); !ok || !v {
+		t.Errorf("Expected (true, true), got (%v, %v)", v, ok)
+	}
+	if v, ok := circ.ConstraintInt("max_items"); !ok || v != 3 {
+		t.Errorf("Expected (3, true), got (%v, %v)", v, ok)
+	}
+	if _, ok := circ.ConstraintInt("partial"); ok {
+		t.Error("Expected ConstraintInt to reject a fractional value")
+	}
+}
+
 // Benchmark tests
 func BenchmarkJobRegistry_RegisterJob(b *testing.B) {
 	registry := NewJobRegistry()
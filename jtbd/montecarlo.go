@@ -0,0 +1,220 @@
+package jtbd
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Distribution is a source of sampled metric values for SimulateOutcome
+type Distribution interface {
+	// Sample draws one value using the supplied random source
+	Sample(r *rand.Rand) float64
+}
+
+// NormalDistribution samples from a normal distribution
+type NormalDistribution struct {
+	Mean   float64
+	StdDev float64
+}
+
+// Sample draws a normally distributed value
+func (d NormalDistribution) Sample(r *rand.Rand) float64 {
+	return d.Mean + r.NormFloat64()*d.StdDev
+}
+
+// UniformDistribution samples uniformly from [Min, Max)
+type UniformDistribution struct {
+	Min float64
+	Max float64
+}
+
+// Sample draws a uniformly distributed value
+func (d UniformDistribution) Sample(r *rand.Rand) float64 {
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+// TriangularDistribution samples from a triangular distribution with the given
+// minimum, most likely and maximum values
+type TriangularDistribution struct {
+	Min  float64
+	Mode float64
+	Max  float64
+}
+
+// Sample draws a triangularly distributed value by inverse transform
+func (d TriangularDistribution) Sample(r *rand.Rand) float64 {
+	span := d.Max - d.Min
+	if span <= 0 {
+		return d.Mode
+	}
+	u := r.Float64()
+	cut := (d.Mode - d.Min) / span
+	if u < cut {
+		return d.Min + math.Sqrt(u*span*(d.Mode-d.Min))
+	}
+	return d.Max - math.Sqrt((1-u)*span*(d.Max-d.Mode))
+}
+
+// SimulationResult summarizes a Monte Carlo simulation of an outcome
+type SimulationResult struct {
+	// Metric is the name of the simulated metric
+	Metric string
+
+	// Trials is the number of samples drawn
+	Trials int
+
+	// ThresholdProbability is the fraction of samples meeting the outcome threshold
+	ThresholdProbability float64
+
+	// TargetProbability is the fraction of samples meeting the outcome target
+	TargetProbability float64
+
+	// Mean is the average sampled value
+	Mean float64
+
+	// Percentiles of the sampled metric
+	P50 float64
+	P90 float64
+	P95 float64
+	P99 float64
+}
+
+// SimulateOutcome samples outcome's metric from dist across trials and reports
+// how likely the outcome is to meet its threshold and target. Comparisons
+// respect the outcome's direction. The same seed always yields the same result.
+func SimulateOutcome(outcome *Outcome, dist Distribution, trials int, seed int64) SimulationResult {
+	result := SimulationResult{Trials: trials}
+	if outcome == nil || dist == nil || trials <= 0 {
+		return result
+	}
+	result.Metric = outcome.Metric
+
+	minimize := outcomeDirection(outcome) == "minimize"
+	meets := func(value, bound float64) bool {
+		if minimize {
+			return value <= bound
+		}
+		return value >= bound
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	samples := make([]float64, trials)
+	var sum float64
+	var metThreshold, metTarget int
+
+	for i := range samples {
+		v := dist.Sample(r)
+		samples[i] = v
+		sum += v
+		if meets(v, outcome.Threshold) {
+			metThreshold++
+		}
+		if meets(v, outcome.Target) {
+			metTarget++
+		}
+	}
+
+	sort.Float64s(samples)
+	result.Mean = sum / float64(trials)
+	result.ThresholdProbability = float64(metThreshold) / float64(trials)
+	result.TargetProbability = float64(metTarget) / float64(trials)
+	result.P50 = samplePercentile(samples, 50)
+	result.P90 = samplePercentile(samples, 90)
+	result.P95 = samplePercentile(samples, 95)
+	result.P99 = samplePercentile(samples, 99)
+
+	return result
+}
+
+// samplePercentile returns the nearest-rank percentile of sorted samples
+func samplePercentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// SensitivityResult describes how far a measured value sits from an outcome's
+// target and threshold
+type SensitivityResult struct {
+	// Metric is the name of the analyzed metric
+	Metric string
+
+	// Direction is "minimize" or "maximize"
+	Direction string
+
+	// TargetMargin is how far the measurement beats the target; negative means it misses
+	TargetMargin float64
+
+	// ThresholdMargin is how far the measurement beats the threshold; negative means it misses
+	ThresholdMargin float64
+
+	// BreakEvenTarget is the target at which the measurement would exactly meet it
+	BreakEvenTarget float64
+}
+
+// SensitivityAnalysis reports signed, direction-aware margins between measured
+// and the outcome's target and threshold. A negative TargetMargin tells how far
+// the target would have to loosen for the outcome to pass.
+func SensitivityAnalysis(outcome *Outcome, measured float64) SensitivityResult {
+	if outcome == nil {
+		return SensitivityResult{}
+	}
+
+	result := SensitivityResult{
+		Metric:          outcome.Metric,
+		Direction:       outcomeDirection(outcome),
+		BreakEvenTarget: measured,
+	}
+
+	if result.Direction == "minimize" {
+		result.TargetMargin = outcome.Target - measured
+		result.ThresholdMargin = outcome.Threshold - measured
+	} else {
+		result.TargetMargin = measured - outcome.Target
+		result.ThresholdMargin = measured - outcome.Threshold
+	}
+
+	return result
+}
+
+// CalibrateTargets returns a copy of outcome whose Target is grounded in
+// history. percentile says how demanding the target is: the share of
+// historical runs it should be at least as good as, so a minimize outcome
+// takes the (100-percentile)th percentile and a maximize outcome the
+// percentile-th. Threshold is set the same way at half the percentile. The
+// copy is unchanged apart from Target and Threshold when history is empty.
+func CalibrateTargets(outcome *Outcome, history []float64, percentile float64) *Outcome {
+	if outcome == nil {
+		return nil
+	}
+
+	calibrated := cloneOutcome(outcome)
+	if len(history) == 0 {
+		return calibrated
+	}
+
+	percentile = math.Max(0, math.Min(100, percentile))
+	sorted := append([]float64(nil), history...)
+	sort.Float64s(sorted)
+
+	minimize := outcomeDirection(outcome) == "minimize"
+	atGoodness := func(p float64) float64 {
+		if minimize {
+			return samplePercentile(sorted, 100-p)
+		}
+		return samplePercentile(sorted, p)
+	}
+
+	calibrated.Target = atGoodness(percentile)
+	calibrated.Threshold = atGoodness(percentile / 2)
+	return calibrated
+}
@@ -0,0 +1,102 @@
+package jtbd
+
+import "testing"
+
+func TestSimulateOutcome_NormalBelowThreshold(t *testing.T) {
+	outcome := &Outcome{
+		Type:      OutcomeTypeSpeed,
+		Metric:    "checkout_time",
+		Target:    30,
+		Threshold: 45,
+		Unit:      "seconds",
+		Direction: "minimize",
+	}
+
+	result := SimulateOutcome(outcome, NormalDistribution{Mean: 30, StdDev: 5}, 10000, 42)
+
+	// 45s is three standard deviations above the mean
+	if result.ThresholdProbability < 0.99 {
+		t.Errorf("Expected threshold probability >= 0.99, got %.4f", result.ThresholdProbability)
+	}
+	// Target sits at the mean, so roughly half the samples meet it
+	if result.TargetProbability < 0.45 || result.TargetProbability > 0.55 {
+		t.Errorf("Expected target probability near 0.5, got %.4f", result.TargetProbability)
+	}
+	if result.P50 > result.P95 || result.P95 > result.P99 {
+		t.Errorf("Expected ordered percentiles, got P50=%.2f P95=%.2f P99=%.2f", result.P50, result.P95, result.P99)
+	}
+
+	again := SimulateOutcome(outcome, NormalDistribution{Mean: 30, StdDev: 5}, 10000, 42)
+	if again != result {
+		t.Error("Expected identical results for the same seed")
+	}
+}
+
+func TestSimulateOutcome_TriangularMaximize(t *testing.T) {
+	outcome := &Outcome{
+		Type:      OutcomeTypeExperience,
+		Metric:    "satisfaction",
+		Threshold: 2,
+		Direction: "maximize",
+	}
+
+	result := SimulateOutcome(outcome, TriangularDistribution{Min: 3, Mode: 4, Max: 5}, 1000, 1)
+
+	if result.ThresholdProbability != 1 {
+		t.Errorf("Expected every sample above threshold, got %.4f", result.ThresholdProbability)
+	}
+	if result.P50 < 3 || result.P99 > 5 {
+		t.Errorf("Expected samples within [3, 5], got P50=%.2f P99=%.2f", result.P50, result.P99)
+	}
+}
+
+func TestSensitivityAnalysis_MinimizeJustAboveTarget(t *testing.T) {
+	outcome := &Outcome{
+		Type:      OutcomeTypeSpeed,
+		Metric:    "checkout_time",
+		Target:    30,
+		Threshold: 45,
+		Direction: "minimize",
+	}
+
+	result := SensitivityAnalysis(outcome, 32)
+
+	if result.TargetMargin != -2 {
+		t.Errorf("Expected target margin -2, got %.2f", result.TargetMargin)
+	}
+	if result.ThresholdMargin != 13 {
+		t.Errorf("Expected threshold margin 13, got %.2f", result.ThresholdMargin)
+	}
+	if result.BreakEvenTarget != 32 {
+		t.Errorf("Expected break-even target 32, got %.2f", result.BreakEvenTarget)
+	}
+}
+
+func TestCalibrateTargets_MinimizeMedian(t *testing.T) {
+	outcome := &Outcome{
+		Type:      OutcomeTypeSpeed,
+		Metric:    "checkout_time",
+		Target:    10,
+		Threshold: 20,
+		Unit:      "seconds",
+	}
+	history := []float64{42, 35, 50, 38, 45, 40, 60, 36, 44}
+
+	calibrated := CalibrateTargets(outcome, history, 50)
+
+	if calibrated.Target != 42 {
+		t.Errorf("Expected target at the median 42, got %.2f", calibrated.Target)
+	}
+	// Minimize thresholds loosen upwards: 25th percentile of goodness is P75
+	if calibrated.Threshold != 45 {
+		t.Errorf("Expected threshold 45, got %.2f", calibrated.Threshold)
+	}
+	if outcome.Target != 10 || outcome.Threshold != 20 {
+		t.Errorf("Original outcome was modified: %+v", outcome)
+	}
+
+	maximize := CalibrateTargets(&Outcome{Metric: "satisfaction", Direction: "maximize"}, history, 90)
+	if maximize.Target != 60 || maximize.Threshold != 42 {
+		t.Errorf("Expected maximize target 60 and threshold 42, got %.2f and %.2f", maximize.Target, maximize.Threshold)
+	}
+}
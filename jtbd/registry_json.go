@@ -0,0 +1,146 @@
+package jtbd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// jobJSON is the serializable form of a Job. Indicators are behaviour, not
+// data, so they are not persisted.
+type jobJSON struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description,omitempty"`
+	Functional    string                 `json:"functional,omitempty"`
+	Emotional     string                 `json:"emotional,omitempty"`
+	Social        string                 `json:"social,omitempty"`
+	Circumstances []*Circumstance        `json:"circumstances,omitempty"`
+	Outcomes      []*Outcome             `json:"outcomes,omitempty"`
+	Industry      string                 `json:"industry,omitempty"`
+	Company       string                 `json:"company,omitempty"`
+	Status        JobStatus              `json:"status,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// newJobJSON captures job's persistable fields
+func newJobJSON(job *Job) jobJSON {
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+
+	return jobJSON{
+		ID:            job.ID,
+		Name:          job.Name,
+		Description:   job.Description,
+		Functional:    job.Functional,
+		Emotional:     job.Emotional,
+		Social:        job.Social,
+		Circumstances: job.Circumstances,
+		Outcomes:      job.Outcomes,
+		Industry:      job.Industry,
+		Company:       job.Company,
+		Status:        job.Status,
+		Metadata:      job.Metadata,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+}
+
+// toJob rebuilds a Job from its serialized form
+func (dto jobJSON) toJob() *Job {
+	job := &Job{
+		ID:            dto.ID,
+		Name:          dto.Name,
+		Description:   dto.Description,
+		Functional:    dto.Functional,
+		Emotional:     dto.Emotional,
+		Social:        dto.Social,
+		Circumstances: dto.Circumstances,
+		Outcomes:      dto.Outcomes,
+		Indicators:    make([]ProgressIndicator, 0),
+		Industry:      dto.Industry,
+		Company:       dto.Company,
+		Status:        dto.Status,
+		Metadata:      dto.Metadata,
+		CreatedAt:     dto.CreatedAt,
+		UpdatedAt:     dto.UpdatedAt,
+	}
+	if job.Metadata == nil {
+		job.Metadata = make(map[string]interface{})
+	}
+	if job.Status == "" {
+		job.Status = JobStatusActive
+	}
+	return job
+}
+
+// ExportJSON serializes every registered job, sorted by ID. Progress
+// indicators are not exported.
+func (jr *JobRegistry) ExportJSON() ([]byte, error) {
+	jobs := jr.ListJobs()
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].ID < jobs[k].ID })
+
+	dtos := make([]jobJSON, len(jobs))
+	for i, job := range jobs {
+		dtos[i] = newJobJSON(job)
+	}
+
+	data, err := json.MarshalIndent(dtos, "", "  ")
+	if err != nil {
+		return nil, NewJTBDError(ErrCodeInternalError, "failed to export jobs", err)
+	}
+	return data, nil
+}
+
+// ImportJSON registers the jobs in data, as produced by ExportJSON, keeping
+// their timestamps and status. Jobs whose ID is already registered are
+// replaced and re-indexed. Every job is validated before any is imported.
+// Numeric metadata comes back as float64, as with any JSON decoding.
+func (jr *JobRegistry) ImportJSON(data []byte) error {
+	var dtos []jobJSON
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return NewJTBDError(ErrCodeInvalidInput, "invalid job registry JSON", err)
+	}
+
+	jobs := make([]*Job, len(dtos))
+	for i, dto := range dtos {
+		job := dto.toJob()
+		if job.ID == "" {
+			return NewJTBDError(ErrCodeInvalidJob, fmt.Sprintf("imported job %d has no ID", i), nil)
+		}
+		if job.Name == "" {
+			return NewJTBDError(ErrCodeInvalidJob, fmt.Sprintf("imported job %q has no name", job.ID), nil)
+		}
+		if err := validateOutcomeDependencies(job.Outcomes); err != nil {
+			return err
+		}
+		jobs[i] = job
+	}
+
+	for _, job := range jobs {
+		jr.importJob(job)
+	}
+	return nil
+}
+
+// importJob stores an already validated job as-is, replacing any job with
+// the same ID
+func (jr *JobRegistry) importJob(job *Job) {
+	var event *RegistryEvent
+	defer jr.notifyAfterUnlock(&event)
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+
+	eventType := RegistryEventAdded
+	if old, exists := jr.jobs[job.ID]; exists {
+		jr.unindexLocked(old)
+		eventType = RegistryEventUpdated
+	}
+	jr.jobs[job.ID] = job
+	jr.indexLocked(job)
+
+	event = &RegistryEvent{Type: eventType, JobID: job.ID, Job: job}
+}
@@ -0,0 +1,92 @@
+package jtbd
+
+import "testing"
+
+func TestJobRegistry_JSONRoundTrip(t *testing.T) {
+	source := NewJobRegistry()
+	jobs := []*Job{
+		{
+			ID:         "walmart-restock",
+			Name:       "Restock pantry",
+			Functional: "Keep the pantry stocked",
+			Industry:   "retail",
+			Company:    "Walmart",
+			Circumstances: []*Circumstance{
+				{Type: CircumstanceTypeTemporal, Constraints: map[string]interface{}{"budget_limit": 150.0}, Intensity: 0.7},
+			},
+			Outcomes: []*Outcome{
+				{Metric: "checkout_time", Target: 60, Threshold: 120, Direction: "minimize"},
+			},
+			Metadata: map[string]interface{}{"region": "us"},
+		},
+		{ID: "target-returns", Name: "Return an item", Industry: "retail", Company: "Target"},
+		{ID: "uhc-claims", Name: "File a claim", Industry: "healthcare", Company: "UnitedHealth"},
+	}
+	for _, job := range jobs {
+		if err := source.RegisterJob(job); err != nil {
+			t.Fatalf("Failed to register %s: %v", job.ID, err)
+		}
+	}
+	if err := source.SetJobStatus("target-returns", JobStatusDeprecated); err != nil {
+		t.Fatalf("Failed to deprecate job: %v", err)
+	}
+
+	data, err := source.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	imported := NewJobRegistry()
+	if err := imported.ImportJSON(data); err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+
+	if got := len(imported.ListJobs()); got != 3 {
+		t.Fatalf("Expected 3 jobs, got %d", got)
+	}
+	if got := len(imported.ListJobsByIndustry("retail")); got != 2 {
+		t.Errorf("Expected 2 retail jobs, got %d", got)
+	}
+	if got := len(imported.ListJobsByIndustry("healthcare")); got != 1 {
+		t.Errorf("Expected 1 healthcare job, got %d", got)
+	}
+	if got := imported.ListJobsByCompany("Walmart"); len(got) != 1 || got[0].ID != "walmart-restock" {
+		t.Errorf("Expected walmart-restock under Walmart, got %d jobs", len(got))
+	}
+
+	for _, want := range jobs {
+		got, err := imported.GetJob(want.ID)
+		if err != nil {
+			t.Fatalf("Imported registry missing %s: %v", want.ID, err)
+		}
+		if got.Name != want.Name || got.Functional != want.Functional || got.Status != want.Status {
+			t.Errorf("%s: expected %q/%q/%s, got %q/%q/%s", want.ID,
+				want.Name, want.Functional, want.Status, got.Name, got.Functional, got.Status)
+		}
+		if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+			t.Errorf("%s: timestamps not preserved", want.ID)
+		}
+	}
+
+	restock, _ := imported.GetJob("walmart-restock")
+	if len(restock.Outcomes) != 1 || restock.Outcomes[0].Target != 60 || restock.Outcomes[0].Direction != "minimize" {
+		t.Errorf("Outcome not preserved: %+v", restock.Outcomes)
+	}
+	if len(restock.Circumstances) != 1 || restock.Circumstances[0].Constraints["budget_limit"] != 150.0 {
+		t.Errorf("Circumstance not preserved: %+v", restock.Circumstances)
+	}
+	if restock.Metadata["region"] != "us" {
+		t.Errorf("Metadata not preserved: %v", restock.Metadata)
+	}
+}
+
+func TestJobRegistry_ImportJSON_RejectsInvalid(t *testing.T) {
+	registry := NewJobRegistry()
+	err := registry.ImportJSON([]byte(`[{"id": "ok", "name": "OK"}, {"id": "nameless"}]`))
+	if jtbdErr, ok := err.(*JTBDError); !ok || jtbdErr.Code != ErrCodeInvalidJob {
+		t.Errorf("Expected invalid_job, got %v", err)
+	}
+	if len(registry.ListJobs()) != 0 {
+		t.Error("Expected nothing to be imported when any job is invalid")
+	}
+}
@@ -0,0 +1,74 @@
+package jtbd
+
+import "sync"
+
+// DefaultReliabilityWindow is how many runs per (job, metric) an
+// OutcomeReliabilityTracker keeps when no window is given
+const DefaultReliabilityWindow = 30
+
+// reliabilityKey identifies one outcome of one job
+type reliabilityKey struct {
+	jobID  string
+	metric string
+}
+
+// OutcomeReliabilityTracker aggregates outcome results across repeated runs
+// of a job, answering "this outcome met its threshold 27 of the last 30 runs".
+type OutcomeReliabilityTracker struct {
+	mu      sync.RWMutex
+	window  int
+	history map[reliabilityKey][]bool
+}
+
+// NewOutcomeReliabilityTracker creates a tracker that retains the last window
+// runs per (job, metric). A non-positive window uses DefaultReliabilityWindow.
+func NewOutcomeReliabilityTracker(window int) *OutcomeReliabilityTracker {
+	if window <= 0 {
+		window = DefaultReliabilityWindow
+	}
+	return &OutcomeReliabilityTracker{
+		window:  window,
+		history: make(map[reliabilityKey][]bool),
+	}
+}
+
+// Record adds one run's outcome results for a job, keyed by metric as
+// returned by Job.EvaluateOutcomes. Not-applicable results are neither met
+// nor missed, so they are skipped.
+func (rt *OutcomeReliabilityTracker) Record(jobID string, results map[string]*OutcomeResult) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	for metric, result := range results {
+		if result == nil || result.NotApplicable {
+			continue
+		}
+		key := reliabilityKey{jobID: jobID, metric: metric}
+		runs := append(rt.history[key], result.MetThreshold)
+		if len(runs) > rt.window {
+			runs = append([]bool(nil), runs[len(runs)-rt.window:]...)
+		}
+		rt.history[key] = runs
+	}
+}
+
+// Reliability returns the fraction of retained runs in which the job's
+// metric met its threshold, along with the number of runs it is based on.
+// Both are zero when nothing has been recorded.
+func (rt *OutcomeReliabilityTracker) Reliability(jobID, metric string) (metRate float64, runs int) {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	history := rt.history[reliabilityKey{jobID: jobID, metric: metric}]
+	if len(history) == 0 {
+		return 0, 0
+	}
+
+	met := 0
+	for _, ok := range history {
+		if ok {
+			met++
+		}
+	}
+	return float64(met) / float64(len(history)), len(history)
+}
@@ -0,0 +1,32 @@
+package jtbd
+
+import "testing"
+
+func TestOutcomeReliabilityTracker_MetRate(t *testing.T) {
+	tracker := NewOutcomeReliabilityTracker(4)
+
+	// Oldest run falls out of the window of 4
+	for _, met := range []bool{false, true, true, false, true} {
+		tracker.Record("walmart-checkout", map[string]*OutcomeResult{
+			"checkout_time": {MetricName: "checkout_time", MetThreshold: met},
+			"accuracy":      {MetricName: "accuracy", MetThreshold: true, NotApplicable: !met},
+		})
+	}
+
+	rate, runs := tracker.Reliability("walmart-checkout", "checkout_time")
+	if runs != 4 {
+		t.Errorf("Expected 4 retained runs, got %d", runs)
+	}
+	if rate != 0.75 {
+		t.Errorf("Expected met rate 0.75, got %.2f", rate)
+	}
+
+	rate, runs = tracker.Reliability("walmart-checkout", "accuracy")
+	if runs != 3 || rate != 1.0 {
+		t.Errorf("Expected not-applicable runs to be skipped (3 runs at 1.0), got %d at %.2f", runs, rate)
+	}
+
+	if rate, runs := tracker.Reliability("target-checkout", "checkout_time"); rate != 0 || runs != 0 {
+		t.Errorf("Expected no history for unrecorded job, got %d at %.2f", runs, rate)
+	}
+}
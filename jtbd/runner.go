@@ -3,9 +3,13 @@ package jtbd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +29,39 @@ const (
 	ExecutionModeComprehensive ExecutionMode = "comprehensive"
 )
 
+// Scheduler selects how parallel and comprehensive runs hand tests to
+// workers.
+type Scheduler string
+
+const (
+	// SchedulerDispatcher pushes ready tests to a shared channel, polling
+	// for newly ready tests. It is the default.
+	SchedulerDispatcher Scheduler = "dispatcher"
+	// SchedulerWorkStealing gives each worker a local deque. Finishing a
+	// test pushes its newly ready dependents onto the finishing worker's
+	// deque, and idle workers steal from the others, so there is no poll
+	// delay between a dependency passing and its dependents starting.
+	SchedulerWorkStealing Scheduler = "work-stealing"
+)
+
+// BackoffStrategy selects how long the engine waits before each retry.
+type BackoffStrategy string
+
+const (
+	// BackoffExponential waits 2^attempt base delays, plus or minus up to
+	// one base delay of seeded jitter. It is the default.
+	BackoffExponential BackoffStrategy = "exponential"
+	// BackoffLinear waits attempt base delays.
+	BackoffLinear BackoffStrategy = "linear"
+	// BackoffConstant waits one base delay before every retry.
+	BackoffConstant BackoffStrategy = "constant"
+	// BackoffNone retries immediately.
+	BackoffNone BackoffStrategy = "none"
+)
+
+// defaultBackoffBase is the base retry delay when RunConfig.BackoffBase is zero.
+const defaultBackoffBase = 100 * time.Millisecond
+
 // TestStatus represents the outcome of a test execution.
 type TestStatus string
 
@@ -35,6 +72,9 @@ const (
 	TestStatusFailed    TestStatus = "failed"
 	TestStatusSkipped   TestStatus = "skipped"
 	TestStatusRetrying  TestStatus = "retrying"
+	// TestStatusQuarantined marks a failure of a quarantined test. It is
+	// reported separately and does not count towards TestMetrics.Failed.
+	TestStatusQuarantined TestStatus = "quarantined"
 )
 
 // Test represents a single test with lifecycle hooks.
@@ -51,6 +91,10 @@ type Test struct {
 	Setup    func(ctx context.Context) error
 	Execute  func(ctx context.Context) error // Required
 	Teardown func(ctx context.Context) error
+
+	// BeforeRetry runs before each retry (never before the first attempt)
+	// so the test can reset partial state. An error stops further retries.
+	BeforeRetry func(ctx context.Context, attempt int) error
 }
 
 // ExecutionResult contains the outcome of a test execution.
@@ -65,6 +109,32 @@ type ExecutionResult struct {
 	EndTime      time.Time     `json:"end_time"`
 	Output       string        `json:"output,omitempty"`
 	SkipReason   string        `json:"skip_reason,omitempty"`
+
+	// OutcomeResults holds outcomes the test reported with RecordOutcome
+	// during its final attempt.
+	OutcomeResults []*OutcomeResult `json:"outcome_results,omitempty"`
+}
+
+// outcomeCollectorKey is the context key for the attempt's outcomeCollector.
+type outcomeCollectorKey struct{}
+
+// outcomeCollector gathers outcome results reported during one attempt.
+type outcomeCollector struct {
+	mu      sync.Mutex
+	results []*OutcomeResult
+}
+
+// RecordOutcome attaches a measured outcome to the running test's
+// ExecutionResult. Call it from Test.Execute with the context it was given;
+// outside an engine run it does nothing.
+func RecordOutcome(ctx context.Context, result *OutcomeResult) {
+	collector, ok := ctx.Value(outcomeCollectorKey{}).(*outcomeCollector)
+	if !ok || result == nil {
+		return
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.results = append(collector.results, result)
 }
 
 // RunConfig configures test execution.
@@ -75,8 +145,67 @@ type RunConfig struct {
 	TestTimeout    time.Duration
 	EnableRetry    bool
 	IsolateTests   bool
+
+	// QuarantinedTests lists known-flaky test IDs. They still run, but
+	// their failures are recorded as TestStatusQuarantined.
+	QuarantinedTests []string
+
+	// MaxTestsPerSecond caps how fast tests are started, independent of
+	// MaxWorkers. Zero means unlimited.
+	MaxTestsPerSecond float64
+
+	// Clock supplies result timestamps. Defaults to the system clock; a
+	// FakeClock makes durations and timestamps reproducible in tests.
+	Clock Clock
+
+	// Seed makes retry backoff jitter reproducible. Zero seeds from the
+	// current time.
+	Seed int64
+
+	// Scheduler picks the parallel scheduling strategy. Empty means
+	// SchedulerDispatcher.
+	Scheduler Scheduler
+
+	// StopOnFirstFailure makes parallel and comprehensive runs cancel the
+	// engine context when any test fails. Running tests see the
+	// cancellation through their context and unstarted tests are skipped.
+	// Quarantined failures don't stop the run.
+	StopOnFirstFailure bool
+
+	// OnResult, if set, is called with each result as it is recorded, for
+	// live progress or streaming logs. Calls are serialized and made
+	// without holding engine locks, so the callback may call GetMetrics.
+	OnResult func(*ExecutionResult)
+
+	// RetryableError decides whether a failed attempt may be retried. The
+	// error wraps the test's own, so errors.Is and errors.As work. Nil
+	// retries every error.
+	RetryableError func(err error) bool
+
+	// Backoff picks the delay strategy between retries. Empty means
+	// BackoffExponential.
+	Backoff BackoffStrategy
+
+	// BackoffBase is the strategy's unit delay. Zero means 100ms.
+	BackoffBase time.Duration
+
+	// BackoffMax caps every retry delay. Zero means no cap.
+	BackoffMax time.Duration
+
+	// Sleeper waits out retry delays. Defaults to time.Sleep; tests can
+	// substitute a no-op or record the delays instead.
+	Sleeper func(time.Duration)
+
+	// StartBudget stops new tests from starting once this long has passed
+	// since Run began. Running tests finish normally; the rest are skipped
+	// with SkipReasonTimeBudget. Zero means no budget.
+	StartBudget time.Duration
 }
 
+// SkipReasonTimeBudget is the skip reason for tests that did not start
+// before RunConfig.StartBudget ran out.
+const SkipReasonTimeBudget = "time budget exhausted"
+
 // DefaultRunConfig returns default configuration.
 func DefaultRunConfig() *RunConfig {
 	return &RunConfig{
@@ -99,21 +228,44 @@ type ExecutionEngine struct {
 	ctx      context.Context
 	cancel   context.CancelFunc
 
+	// dispatchCtx gates starting new tests. It is ctx, narrowed by
+	// StartBudget once Run begins.
+	dispatchCtx context.Context
+
 	// Metrics (atomic)
 	totalTests    atomic.Int32
 	passedTests   atomic.Int32
 	failedTests   atomic.Int32
 	skippedTests  atomic.Int32
+	budgetSkipped atomic.Int32
 	retryAttempts atomic.Int32
+	quarantined   atomic.Int32
 
 	// Results
-	results   []*ExecutionResult
-	resultsMu sync.Mutex
+	results    []*ExecutionResult
+	resultsMu  sync.Mutex
+	onResultMu sync.Mutex // serializes RunConfig.OnResult calls
 
 	// Shared state
 	mu              sync.RWMutex
 	completedTests  map[string]bool
 	failedTestsList map[string]bool
+	quarantinedSet  map[string]bool
+
+	// blockedBy maps tests skipped because of failed dependencies to the
+	// failed tests at the root of the chain
+	blockedBy map[string][]string
+
+	limiter *rateLimiter
+
+	// rng drives retry jitter; rand.Rand is not safe for concurrent use
+	rng   *rand.Rand
+	rngMu sync.Mutex
+
+	// stoppedBy is the test whose failure canceled the run under
+	// StopOnFirstFailure. Written once under stopOnce.
+	stopOnce  sync.Once
+	stoppedBy string
 }
 
 // ExecutionPlan determines test execution order based on dependencies.
@@ -132,6 +284,13 @@ type TestMetrics struct {
 	Failed   int32
 	Skipped  int32
 	Retries  int32
+
+	// Quarantined counts failures of quarantined tests.
+	Quarantined int32
+
+	// BudgetSkipped counts skipped tests that were left out to fit a time
+	// budget. They are also counted in Skipped.
+	BudgetSkipped int32
 }
 
 // NewExecutionEngine creates a new test execution engine.
@@ -151,6 +310,18 @@ func NewExecutionEngine(tests []*Test, config *RunConfig) (*ExecutionEngine, err
 		config.MaxWorkers = 100 // Safety cap
 	}
 
+	switch config.Scheduler {
+	case "", SchedulerDispatcher, SchedulerWorkStealing:
+	default:
+		return nil, fmt.Errorf("unknown scheduler: %s", config.Scheduler)
+	}
+
+	switch config.Backoff {
+	case "", BackoffExponential, BackoffLinear, BackoffConstant, BackoffNone:
+	default:
+		return nil, fmt.Errorf("unknown backoff strategy: %s", config.Backoff)
+	}
+
 	plan, err := NewExecutionPlan(tests)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create execution plan: %w", err)
@@ -165,9 +336,23 @@ func NewExecutionEngine(tests []*Test, config *RunConfig) (*ExecutionEngine, err
 		workChan:        make(chan *Test, len(tests)),
 		ctx:             ctx,
 		cancel:          cancel,
+		dispatchCtx:     ctx,
 		results:         make([]*ExecutionResult, 0, len(tests)),
 		completedTests:  make(map[string]bool),
 		failedTestsList: make(map[string]bool),
+		quarantinedSet:  make(map[string]bool, len(config.QuarantinedTests)),
+		blockedBy:       make(map[string][]string),
+	}
+	for _, id := range config.QuarantinedTests {
+		ee.quarantinedSet[id] = true
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	ee.rng = rand.New(rand.NewSource(seed))
+	if config.MaxTestsPerSecond > 0 {
+		ee.limiter = newRateLimiter(config.MaxTestsPerSecond)
 	}
 
 	ee.totalTests.Store(int32(len(tests)))
@@ -179,6 +364,12 @@ func NewExecutionEngine(tests []*Test, config *RunConfig) (*ExecutionEngine, err
 func (ee *ExecutionEngine) Run() ([]*ExecutionResult, error) {
 	defer ee.cancel()
 
+	if ee.config.StartBudget > 0 {
+		var stop context.CancelFunc
+		ee.dispatchCtx, stop = context.WithTimeout(ee.ctx, ee.config.StartBudget)
+		defer stop()
+	}
+
 	switch ee.config.Mode {
 	case ExecutionModeSequential:
 		return ee.runSequential()
@@ -201,15 +392,25 @@ func (ee *ExecutionEngine) runSequential() ([]*ExecutionResult, error) {
 	}
 
 	for _, test := range ordered {
+		if ee.dispatchCtx.Err() != nil {
+			ee.skipTest(test, ee.unstartedReason())
+			continue
+		}
+
 		if !ee.shouldRunTest(test) {
-			ee.skipTest(test, "dependencies failed")
+			ee.skipForDependencies(test)
+			continue
+		}
+
+		if err := ee.limiter.wait(ee.dispatchCtx); err != nil {
+			ee.skipTest(test, ee.unstartedReason())
 			continue
 		}
 
 		result := ee.executeTest(ee.ctx, test)
 		ee.recordResult(result)
 
-		if result.Status == TestStatusFailed {
+		if result.Status == TestStatusFailed || result.Status == TestStatusQuarantined {
 			ee.markTestFailed(test.ID)
 		} else if result.Status == TestStatusPassed {
 			ee.markTestCompleted(test.ID)
@@ -221,6 +422,11 @@ func (ee *ExecutionEngine) runSequential() ([]*ExecutionResult, error) {
 
 // runParallel executes independent tests concurrently.
 func (ee *ExecutionEngine) runParallel() ([]*ExecutionResult, error) {
+	if ee.config.Scheduler == SchedulerWorkStealing {
+		ee.runWorkStealing()
+		return ee.results, ee.stopError()
+	}
+
 	// Start worker pool
 	for i := 0; i < ee.config.MaxWorkers; i++ {
 		ee.wg.Add(1)
@@ -236,7 +442,48 @@ func (ee *ExecutionEngine) runParallel() ([]*ExecutionResult, error) {
 	// Wait for all workers to complete
 	ee.wg.Wait()
 
-	return ee.results, nil
+	if ee.dispatchCtx.Err() != nil {
+		ee.skipUnrecorded(ee.unstartedReason())
+	}
+	return ee.results, ee.stopError()
+}
+
+// stopOnFailure cancels the run after testID failed, if the config asks
+// for it. Only the first failure is remembered.
+func (ee *ExecutionEngine) stopOnFailure(testID string) {
+	if !ee.config.StopOnFirstFailure {
+		return
+	}
+	ee.stopOnce.Do(func() {
+		ee.stoppedBy = testID
+		ee.cancel()
+	})
+}
+
+// stopError reports the failure that stopped the run, if any. Call it only
+// after every worker has finished.
+func (ee *ExecutionEngine) stopError() error {
+	if ee.stoppedBy == "" {
+		return nil
+	}
+	return fmt.Errorf("test failed: %s", ee.stoppedBy)
+}
+
+// skipUnrecorded records a skip for every test without a result, such as
+// those never dispatched before the run was canceled.
+func (ee *ExecutionEngine) skipUnrecorded(reason string) {
+	ee.resultsMu.Lock()
+	recorded := make(map[string]bool, len(ee.results))
+	for _, result := range ee.results {
+		recorded[result.TestID] = true
+	}
+	ee.resultsMu.Unlock()
+
+	for _, test := range ee.tests {
+		if !recorded[test.ID] {
+			ee.skipTest(test, reason)
+		}
+	}
 }
 
 // runFailFast executes tests and stops on first failure.
@@ -248,16 +495,28 @@ func (ee *ExecutionEngine) runFailFast() ([]*ExecutionResult, error) {
 
 	for _, test := range ordered {
 		select {
-		case <-ee.ctx.Done():
+		case <-ee.dispatchCtx.Done():
+			if ee.ctx.Err() == nil {
+				ee.skipUnrecorded(SkipReasonTimeBudget)
+				return ee.results, nil
+			}
 			return ee.results, ee.ctx.Err()
 		default:
 		}
 
 		if !ee.shouldRunTest(test) {
-			ee.skipTest(test, "dependencies failed")
+			ee.skipForDependencies(test)
 			continue
 		}
 
+		if err := ee.limiter.wait(ee.dispatchCtx); err != nil {
+			if ee.ctx.Err() == nil {
+				ee.skipUnrecorded(SkipReasonTimeBudget)
+				return ee.results, nil
+			}
+			return ee.results, err
+		}
+
 		result := ee.executeTest(ee.ctx, test)
 		ee.recordResult(result)
 
@@ -265,6 +524,12 @@ func (ee *ExecutionEngine) runFailFast() ([]*ExecutionResult, error) {
 			ee.markTestFailed(test.ID)
 			return ee.results, fmt.Errorf("test failed: %s", test.ID)
 		}
+		if result.Status == TestStatusQuarantined {
+			// Quarantined failures don't abort the run, but dependents
+			// still must not run against a failed prerequisite.
+			ee.markTestFailed(test.ID)
+			continue
+		}
 
 		ee.markTestCompleted(test.ID)
 	}
@@ -283,28 +548,39 @@ func (ee *ExecutionEngine) worker(id int) {
 
 	for test := range ee.workChan {
 		select {
-		case <-ee.ctx.Done():
-			ee.skipTest(test, "context canceled")
-			return
+		case <-ee.dispatchCtx.Done():
+			// Keep draining so every queued test gets a result
+			ee.skipTest(test, ee.unstartedReason())
+			continue
 		default:
 		}
 
 		if !ee.shouldRunTest(test) {
-			ee.skipTest(test, "dependencies not met")
+			ee.skipForDependencies(test)
 			continue
 		}
 
-		result := ee.executeTest(ee.ctx, test)
-		ee.recordResult(result)
+		ee.runAndRecord(test)
+	}
+}
 
-		if result.Status == TestStatusPassed {
-			ee.markTestCompleted(test.ID)
-			ee.plan.MarkCompleted(test.ID)
-		} else if result.Status == TestStatusFailed {
-			ee.markTestFailed(test.ID)
-			ee.plan.MarkFailed(test.ID)
-		}
+// runAndRecord executes a test, records its result and updates dependency
+// state for both the engine and the plan.
+func (ee *ExecutionEngine) runAndRecord(test *Test) TestStatus {
+	result := ee.executeTest(ee.ctx, test)
+	ee.recordResult(result)
+
+	if result.Status == TestStatusPassed {
+		ee.markTestCompleted(test.ID)
+		ee.plan.MarkCompleted(test.ID)
+	} else if result.Status == TestStatusFailed || result.Status == TestStatusQuarantined {
+		ee.markTestFailed(test.ID)
+		ee.plan.MarkFailed(test.ID)
 	}
+	if result.Status == TestStatusFailed {
+		ee.stopOnFailure(test.ID)
+	}
+	return result.Status
 }
 
 // dispatchTests sends tests to workers as dependencies are satisfied.
@@ -313,11 +589,19 @@ func (ee *ExecutionEngine) dispatchTests() {
 
 	for {
 		select {
-		case <-ee.ctx.Done():
+		case <-ee.dispatchCtx.Done():
 			return
 		default:
 		}
 
+		// Tests behind a failed dependency will never become ready
+		for _, test := range ee.tests {
+			if !dispatched[test.ID] && len(ee.failedDependencies(test)) > 0 {
+				dispatched[test.ID] = true
+				ee.skipForDependencies(test)
+			}
+		}
+
 		// Find tests ready to run
 		ready := ee.plan.GetReadyTests()
 		if len(ready) == 0 {
@@ -331,6 +615,9 @@ func (ee *ExecutionEngine) dispatchTests() {
 
 		for _, test := range ready {
 			if !dispatched[test.ID] {
+				if err := ee.limiter.wait(ee.dispatchCtx); err != nil {
+					return
+				}
 				dispatched[test.ID] = true
 				ee.workChan <- test
 			}
@@ -342,7 +629,7 @@ func (ee *ExecutionEngine) dispatchTests() {
 func (ee *ExecutionEngine) executeTest(ctx context.Context, test *Test) *ExecutionResult {
 	result := &ExecutionResult{
 		TestID:    test.ID,
-		StartTime: time.Now(),
+		StartTime: ee.now(),
 	}
 
 	maxAttempts := 1
@@ -353,36 +640,161 @@ func (ee *ExecutionEngine) executeTest(ctx context.Context, test *Test) *Executi
 	var lastErr error
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		if attempt > 0 {
+			if err := ctx.Err(); err != nil {
+				lastErr = fmt.Errorf("%w (retry canceled: %w)", lastErr, err)
+				break
+			}
 			result.RetryCount = attempt
 			ee.retryAttempts.Add(1)
-			// Exponential backoff with jitter
-			baseDelay := 100 * time.Millisecond
-			backoff := time.Duration(math.Pow(2, float64(attempt))) * baseDelay
-			jitter := time.Duration(rand.Float64()*float64(baseDelay)*2 - float64(baseDelay))
-			time.Sleep(backoff + jitter)
+			if err := ee.sleep(ctx, ee.retryDelay(attempt)); err != nil {
+				lastErr = fmt.Errorf("%w (retry canceled: %w)", lastErr, err)
+				break
+			}
+
+			if test.BeforeRetry != nil {
+				if err := test.BeforeRetry(ctx, attempt); err != nil {
+					lastErr = fmt.Errorf("before retry failed: %w", err)
+					break
+				}
+			}
 		}
 
-		err := ee.runTestLifecycle(ctx, test)
+		collector := &outcomeCollector{}
+		err := ee.runTestLifecycle(context.WithValue(ctx, outcomeCollectorKey{}, collector), test)
+		result.OutcomeResults = collector.results
 		if err == nil {
 			result.Status = TestStatusPassed
-			result.EndTime = time.Now()
+			result.EndTime = ee.now()
 			result.Duration = result.EndTime.Sub(result.StartTime)
 			return result
 		}
 
 		lastErr = err
+		if ee.config.RetryableError != nil && !ee.config.RetryableError(err) {
+			break
+		}
 	}
 
 	result.Status = TestStatusFailed
+	if ee.quarantinedSet[test.ID] {
+		result.Status = TestStatusQuarantined
+	}
 	result.Error = lastErr
 	result.ErrorMessage = lastErr.Error()
-	result.EndTime = time.Now()
+	result.EndTime = ee.now()
 	result.Duration = result.EndTime.Sub(result.StartTime)
 	return result
 }
 
-// runTestLifecycle executes setup, execute, and teardown.
-func (ee *ExecutionEngine) runTestLifecycle(ctx context.Context, test *Test) error {
+// retryDelay returns how long to wait before the given retry attempt,
+// starting at 1, under the configured backoff strategy and cap.
+func (ee *ExecutionEngine) retryDelay(attempt int) time.Duration {
+	base := ee.config.BackoffBase
+	if base <= 0 {
+		base = defaultBackoffBase
+	}
+
+	var delay time.Duration
+	switch ee.config.Backoff {
+	case BackoffNone:
+		return 0
+	case BackoffConstant:
+		delay = base
+	case BackoffLinear:
+		delay = time.Duration(attempt) * base
+	default:
+		backoff := time.Duration(math.Pow(2, float64(attempt))) * base
+		jitter := time.Duration(ee.randFloat()*float64(base)*2 - float64(base))
+		delay = backoff + jitter
+	}
+
+	if ee.config.BackoffMax > 0 && delay > ee.config.BackoffMax {
+		delay = ee.config.BackoffMax
+	}
+	return delay
+}
+
+// sleep waits for d using the configured Sleeper, or a timer that gives up
+// early when ctx is done. It returns ctx's error if the run was canceled.
+func (ee *ExecutionEngine) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	if ee.config.Sleeper != nil {
+		ee.config.Sleeper(d)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// randFloat returns the next value from the engine's seeded random source.
+func (ee *ExecutionEngine) randFloat() float64 {
+	ee.rngMu.Lock()
+	defer ee.rngMu.Unlock()
+	return ee.rng.Float64()
+}
+
+// rateLimiter is a token bucket with a burst of one: it hands out one token
+// every interval. A nil limiter never blocks.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter creates a limiter allowing perSecond tokens per second.
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	if rl == nil {
+		return nil
+	}
+
+	rl.mu.Lock()
+	now := time.Now()
+	slot := rl.next
+	if slot.Before(now) {
+		slot = now
+	}
+	rl.next = slot.Add(rl.interval)
+	rl.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// runTestLifecycle executes setup, execute, and teardown. A panic in any
+// phase is recovered and returned as an error carrying the stack trace, after
+// teardown has run.
+func (ee *ExecutionEngine) runTestLifecycle(ctx context.Context, test *Test) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("test panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
 	// Create test-specific context with timeout
 	timeout := ee.config.TestTimeout
 	if test.Timeout > 0 {
@@ -435,8 +847,20 @@ func (ee *ExecutionEngine) shouldRunTest(test *Test) bool {
 	return true
 }
 
-// recordResult adds a result to the results list.
+// recordResult adds a result to the results list and reports it to
+// RunConfig.OnResult.
 func (ee *ExecutionEngine) recordResult(result *ExecutionResult) {
+	ee.appendResult(result)
+
+	if ee.config.OnResult != nil {
+		ee.onResultMu.Lock()
+		defer ee.onResultMu.Unlock()
+		ee.config.OnResult(result)
+	}
+}
+
+// appendResult stores a result and updates the status counters.
+func (ee *ExecutionEngine) appendResult(result *ExecutionResult) {
 	ee.resultsMu.Lock()
 	defer ee.resultsMu.Unlock()
 
@@ -449,21 +873,93 @@ func (ee *ExecutionEngine) recordResult(result *ExecutionResult) {
 		ee.failedTests.Add(1)
 	case TestStatusSkipped:
 		ee.skippedTests.Add(1)
+	case TestStatusQuarantined:
+		ee.quarantined.Add(1)
 	}
 }
 
+// failedDependencies returns the sorted IDs of failed tests that test
+// depends on, directly or through dependencies skipped because of them.
+func (ee *ExecutionEngine) failedDependencies(test *Test) []string {
+	ee.mu.RLock()
+	defer ee.mu.RUnlock()
+
+	roots := make(map[string]bool)
+	for _, depID := range test.Dependencies {
+		if ee.failedTestsList[depID] {
+			roots[depID] = true
+		}
+		for _, root := range ee.blockedBy[depID] {
+			roots[root] = true
+		}
+	}
+
+	failed := make([]string, 0, len(roots))
+	for id := range roots {
+		failed = append(failed, id)
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// skipForDependencies skips a test whose dependencies are unsatisfied,
+// naming the failed dependencies responsible when there are any.
+func (ee *ExecutionEngine) skipForDependencies(test *Test) {
+	failed := ee.failedDependencies(test)
+	if len(failed) == 0 {
+		ee.skipTest(test, "dependencies not met")
+		return
+	}
+
+	ee.mu.Lock()
+	ee.blockedBy[test.ID] = failed
+	ee.mu.Unlock()
+	ee.plan.MarkFailed(test.ID)
+
+	ee.skipTest(test, dependencyFailedReason(failed))
+}
+
+// dependencyFailedReason describes failed dependencies as a skip reason.
+func dependencyFailedReason(failed []string) string {
+	if len(failed) == 1 {
+		return fmt.Sprintf("dependency '%s' failed", failed[0])
+	}
+	return fmt.Sprintf("dependencies '%s' failed", strings.Join(failed, "', '"))
+}
+
 // skipTest marks a test as skipped.
 func (ee *ExecutionEngine) skipTest(test *Test, reason string) {
+	now := ee.now()
 	result := &ExecutionResult{
 		TestID:     test.ID,
 		Status:     TestStatusSkipped,
 		SkipReason: reason,
-		StartTime:  time.Now(),
-		EndTime:    time.Now(),
+		StartTime:  now,
+		EndTime:    now,
+	}
+	if reason == SkipReasonTimeBudget {
+		ee.budgetSkipped.Add(1)
 	}
 	ee.recordResult(result)
 }
 
+// unstartedReason explains why a test never started: the run was canceled
+// or timed out, or only the StartBudget ran out.
+func (ee *ExecutionEngine) unstartedReason() string {
+	if ee.ctx.Err() == nil && ee.dispatchCtx.Err() != nil {
+		return SkipReasonTimeBudget
+	}
+	return "context canceled"
+}
+
+// now returns the current time from the configured clock.
+func (ee *ExecutionEngine) now() time.Time {
+	if ee.config.Clock != nil {
+		return ee.config.Clock.Now()
+	}
+	return time.Now()
+}
+
 // markTestCompleted marks a test as completed.
 func (ee *ExecutionEngine) markTestCompleted(testID string) {
 	ee.mu.Lock()
@@ -504,13 +1000,17 @@ func (ee *ExecutionEngine) GetMetrics() TestMetrics {
 		Failed:  ee.failedTests.Load(),
 		Skipped: ee.skippedTests.Load(),
 		Retries: ee.retryAttempts.Load(),
+
+		BudgetSkipped: ee.budgetSkipped.Load(),
+
+		Quarantined: ee.quarantined.Load(),
 	}
 }
 
 // String returns a string representation of test metrics.
 func (tm TestMetrics) String() string {
-	return fmt.Sprintf("Tests: %d total, %d passed, %d failed, %d skipped (retries: %d)",
-		tm.Total, tm.Passed, tm.Failed, tm.Skipped, tm.Retries)
+	return fmt.Sprintf("Tests: %d total, %d passed, %d failed, %d skipped, %d quarantined (retries: %d)",
+		tm.Total, tm.Passed, tm.Failed, tm.Skipped, tm.Quarantined, tm.Retries)
 }
 
 // NewExecutionPlan creates an execution plan with dependency resolution.
@@ -527,6 +1027,10 @@ func NewExecutionPlan(tests []*Test) (*ExecutionPlan, error) {
 		plan.dependencies[test.ID] = test.Dependencies
 	}
 
+	if err := plan.validateDependencies(); err != nil {
+		return nil, err
+	}
+
 	// Validate no circular dependencies
 	if err := plan.detectCircularDependencies(); err != nil {
 		return nil, err
@@ -536,51 +1040,45 @@ func NewExecutionPlan(tests []*Test) (*ExecutionPlan, error) {
 }
 
 // GetExecutionOrder returns tests in dependency-respecting order (topological sort).
+// It fails if any test depends on an ID that is not in the plan.
 func (ep *ExecutionPlan) GetExecutionOrder() ([]*Test, error) {
 	ep.mu.RLock()
 	defer ep.mu.RUnlock()
 
-	// Build in-degree map
-	inDegree := make(map[string]int)
+	if err := ep.checkUnknownDependencies(); err != nil {
+		return nil, err
+	}
+
+	// In-degree is the number of dependencies each test waits on, and
+	// dependents maps each test to the tests waiting on it
+	inDegree := make(map[string]int, len(ep.tests))
+	dependents := make(map[string][]*Test)
 	for _, test := range ep.tests {
-		if _, exists := inDegree[test.ID]; !exists {
-			inDegree[test.ID] = 0
-		}
+		inDegree[test.ID] = len(test.Dependencies)
 		for _, dep := range test.Dependencies {
-			inDegree[dep]++
+			dependents[dep] = append(dependents[dep], test)
 		}
 	}
 
-	// Topological sort using Kahn's algorithm
+	// Topological sort using Kahn's algorithm, starting from tests with
+	// no dependencies
 	var ordered []*Test
 	queue := make([]*Test, 0)
-
-	// Find tests with no dependencies
 	for _, test := range ep.tests {
-		if len(test.Dependencies) == 0 {
+		if inDegree[test.ID] == 0 {
 			queue = append(queue, test)
 		}
 	}
 
-	testMap := make(map[string]*Test)
-	for _, test := range ep.tests {
-		testMap[test.ID] = test
-	}
-
 	for len(queue) > 0 {
 		current := queue[0]
 		queue = queue[1:]
 		ordered = append(ordered, current)
 
-		// Find tests that depend on current
-		for _, test := range ep.tests {
-			for _, dep := range test.Dependencies {
-				if dep == current.ID {
-					inDegree[test.ID]--
-					if inDegree[test.ID] == 0 {
-						queue = append(queue, test)
-					}
-				}
+		for _, test := range dependents[current.ID] {
+			inDegree[test.ID]--
+			if inDegree[test.ID] == 0 {
+				queue = append(queue, test)
 			}
 		}
 	}
@@ -592,7 +1090,60 @@ func (ep *ExecutionPlan) GetExecutionOrder() ([]*Test, error) {
 	return ordered, nil
 }
 
-// GetReadyTests returns tests whose dependencies are satisfied.
+// validateDependencies reports every self-dependency and unknown
+// dependency at once, so they can all be fixed before anything runs.
+func (ep *ExecutionPlan) validateDependencies() error {
+	var errs []error
+	for _, test := range ep.tests {
+		for _, dep := range test.Dependencies {
+			if dep == test.ID {
+				errs = append(errs, fmt.Errorf("test %s depends on itself", test.ID))
+				break
+			}
+		}
+	}
+	if err := ep.checkUnknownDependencies(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// checkUnknownDependencies reports every dependency that names no test in
+// the plan, along with the tests that declare it.
+func (ep *ExecutionPlan) checkUnknownDependencies() error {
+	known := make(map[string]bool, len(ep.tests))
+	for _, test := range ep.tests {
+		known[test.ID] = true
+	}
+
+	requiredBy := make(map[string][]string)
+	var unknown []string
+	for _, test := range ep.tests {
+		for _, dep := range test.Dependencies {
+			if known[dep] {
+				continue
+			}
+			if _, seen := requiredBy[dep]; !seen {
+				unknown = append(unknown, dep)
+			}
+			requiredBy[dep] = append(requiredBy[dep], test.ID)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	descriptions := make([]string, len(unknown))
+	for i, dep := range unknown {
+		descriptions[i] = fmt.Sprintf("%s (required by %s)", dep, strings.Join(requiredBy[dep], ", "))
+	}
+	return fmt.Errorf("unknown dependency IDs: %s", strings.Join(descriptions, "; "))
+}
+
+// GetReadyTests returns tests whose dependencies are satisfied, highest
+// Priority first so critical tests start first when workers are scarce.
+// Tests of equal priority keep their original order.
 func (ep *ExecutionPlan) GetReadyTests() []*Test {
 	ep.mu.RLock()
 	defer ep.mu.RUnlock()
@@ -616,6 +1167,7 @@ func (ep *ExecutionPlan) GetReadyTests() []*Test {
 		}
 	}
 
+	sort.SliceStable(ready, func(i, j int) bool { return ready[i].Priority > ready[j].Priority })
 	return ready
 }
 
@@ -640,8 +1192,8 @@ func (ep *ExecutionPlan) detectCircularDependencies() error {
 
 	for _, test := range ep.tests {
 		if !visited[test.ID] {
-			if ep.hasCycle(test.ID, visited, recStack) {
-				return fmt.Errorf("circular dependency detected involving test: %s", test.ID)
+			if cycle := ep.findCycle(test.ID, visited, recStack, nil); cycle != nil {
+				return fmt.Errorf("circular dependency detected: %s", strings.Join(cycle, " -> "))
 			}
 		}
 	}
@@ -649,21 +1201,112 @@ func (ep *ExecutionPlan) detectCircularDependencies() error {
 	return nil
 }
 
-// hasCycle performs DFS to detect cycles.
-func (ep *ExecutionPlan) hasCycle(testID string, visited, recStack map[string]bool) bool {
+// findCycle performs DFS to detect cycles. path holds the current recursion
+// stack in order; when a back-edge is found the cycle is returned following
+// dependency direction and closed with its first test, e.g. [a b c a].
+func (ep *ExecutionPlan) findCycle(testID string, visited, recStack map[string]bool, path []string) []string {
 	visited[testID] = true
 	recStack[testID] = true
+	path = append(path, testID)
 
 	for _, depID := range ep.dependencies[testID] {
 		if !visited[depID] {
-			if ep.hasCycle(depID, visited, recStack) {
-				return true
+			if cycle := ep.findCycle(depID, visited, recStack, path); cycle != nil {
+				return cycle
 			}
 		} else if recStack[depID] {
-			return true
+			for i, id := range path {
+				if id == depID {
+					cycle := append([]string{}, path[i:]...)
+					return append(cycle, depID)
+				}
+			}
 		}
 	}
 
 	recStack[testID] = false
-	return false
+	return nil
+}
+
+// EstimateDuration estimates the wall-clock time to run tests under config.
+// Tests without history use the average historical duration, or the
+// configured TestTimeout when there is no history at all. Sequential runs
+// take the sum of durations; parallel runs take the longer of the dependency
+// critical path and the total work spread across MaxWorkers.
+func EstimateDuration(tests []*Test, history map[string]time.Duration, config *RunConfig) time.Duration {
+	if len(tests) == 0 {
+		return 0
+	}
+	if config == nil {
+		config = DefaultRunConfig()
+	}
+
+	fallback := config.TestTimeout
+	var known time.Duration
+	var knownCount int
+	for _, test := range tests {
+		if d, ok := history[test.ID]; ok {
+			known += d
+			knownCount++
+		}
+	}
+	if knownCount > 0 {
+		fallback = known / time.Duration(knownCount)
+	}
+
+	byID := make(map[string]*Test, len(tests))
+	durations := make(map[string]time.Duration, len(tests))
+	var total time.Duration
+	for _, test := range tests {
+		byID[test.ID] = test
+		d, ok := history[test.ID]
+		if !ok {
+			d = fallback
+		}
+		durations[test.ID] = d
+		total += d
+	}
+
+	workers := config.MaxWorkers
+	if config.Mode == ExecutionModeSequential || workers <= 1 {
+		return total
+	}
+
+	// finish[id] is the earliest time id can complete given its dependencies
+	finish := make(map[string]time.Duration, len(tests))
+	visiting := make(map[string]bool)
+	var earliestFinish func(id string) time.Duration
+	earliestFinish = func(id string) time.Duration {
+		if f, ok := finish[id]; ok {
+			return f
+		}
+		if visiting[id] {
+			return 0 // cycle; NewExecutionPlan rejects these anyway
+		}
+		visiting[id] = true
+		var start time.Duration
+		for _, depID := range byID[id].Dependencies {
+			if _, ok := byID[depID]; !ok {
+				continue
+			}
+			if f := earliestFinish(depID); f > start {
+				start = f
+			}
+		}
+		visiting[id] = false
+		finish[id] = start + durations[id]
+		return finish[id]
+	}
+
+	var criticalPath time.Duration
+	for _, test := range tests {
+		if f := earliestFinish(test.ID); f > criticalPath {
+			criticalPath = f
+		}
+	}
+
+	if spread := total / time.Duration(workers); spread > criticalPath {
+		return spread
+	}
+	return criticalPath
 }
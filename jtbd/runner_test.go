@@ -0,0 +1,1227 @@
+package jtbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecutionEngine_QuarantinedTests(t *testing.T) {
+	tests := []*Test{
+		{
+			ID:      "stable",
+			Name:    "Stable Test",
+			Execute: func(ctx context.Context) error { return nil },
+		},
+		{
+			ID:      "flaky",
+			Name:    "Flaky Test",
+			Execute: func(ctx context.Context) error { return errors.New("always fails") },
+		},
+	}
+
+	config := &RunConfig{
+		Mode:             ExecutionModeSequential,
+		MaxWorkers:       1,
+		GlobalTimeout:    10 * time.Second,
+		TestTimeout:      time.Second,
+		QuarantinedTests: []string{"flaky"},
+	}
+
+	engine, err := NewExecutionEngine(tests, config)
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	metrics := engine.GetMetrics()
+	if metrics.Failed != 0 {
+		t.Errorf("Expected 0 failed tests, got %d", metrics.Failed)
+	}
+	if metrics.Quarantined != 1 {
+		t.Errorf("Expected 1 quarantined failure, got %d", metrics.Quarantined)
+	}
+
+	var found bool
+	for _, result := range results {
+		if result.TestID != "flaky" {
+			continue
+		}
+		found = true
+		if result.Status != TestStatusQuarantined {
+			t.Errorf("Expected status %s, got %s", TestStatusQuarantined, result.Status)
+		}
+		if result.ErrorMessage == "" {
+			t.Error("Expected quarantined failure to keep its error message")
+		}
+	}
+	if !found {
+		t.Error("Expected a result for the quarantined test")
+	}
+}
+
+func TestExecutionEngine_FakeClockDuration(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []*Test{
+		{
+			ID: "timed",
+			Execute: func(ctx context.Context) error {
+				clock.Advance(250 * time.Millisecond)
+				return nil
+			},
+		},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		Clock:         clock,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Duration != 250*time.Millisecond {
+		t.Errorf("Expected duration of exactly 250ms, got %v", results[0].Duration)
+	}
+	if !results[0].EndTime.Equal(clock.Now()) {
+		t.Errorf("Expected end time %v, got %v", clock.Now(), results[0].EndTime)
+	}
+}
+
+func TestExecutionPlan_CircularDependencyPath(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []*Test{
+		{ID: "a", Dependencies: []string{"b"}, Execute: noop},
+		{ID: "b", Dependencies: []string{"c"}, Execute: noop},
+		{ID: "c", Dependencies: []string{"a"}, Execute: noop},
+	}
+
+	_, err := NewExecutionPlan(tests)
+	if err == nil {
+		t.Fatal("Expected circular dependency error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected full cycle path in error, got %q", err.Error())
+	}
+}
+
+func TestExecutionPlan_GetExecutionOrder(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+
+	// A chain and a diamond, listed out of order
+	tests := []*Test{
+		{ID: "leaf", Dependencies: []string{"mid"}, Execute: noop},
+		{ID: "join", Dependencies: []string{"left", "right"}, Execute: noop},
+		{ID: "mid", Dependencies: []string{"root"}, Execute: noop},
+		{ID: "left", Dependencies: []string{"root"}, Execute: noop},
+		{ID: "right", Dependencies: []string{"root"}, Execute: noop},
+		{ID: "root", Execute: noop},
+	}
+	plan, err := NewExecutionPlan(tests)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %v", err)
+	}
+
+	ordered, err := plan.GetExecutionOrder()
+	if err != nil {
+		t.Fatalf("GetExecutionOrder failed: %v", err)
+	}
+	if len(ordered) != len(tests) {
+		t.Fatalf("Expected %d tests in order, got %d", len(tests), len(ordered))
+	}
+	position := make(map[string]int)
+	for i, test := range ordered {
+		position[test.ID] = i
+	}
+	for _, test := range tests {
+		for _, dep := range test.Dependencies {
+			if position[dep] > position[test.ID] {
+				t.Errorf("Expected %s before %s", dep, test.ID)
+			}
+		}
+	}
+}
+
+func TestExecutionEngine_SequentialRunsDependencyChain(t *testing.T) {
+	var order []string
+	record := func(id string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			order = append(order, id)
+			return nil
+		}
+	}
+	tests := []*Test{
+		{ID: "c", Dependencies: []string{"b"}, Execute: record("c")},
+		{ID: "b", Dependencies: []string{"a"}, Execute: record("b")},
+		{ID: "a", Execute: record("a")},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	if _, err := engine.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "a,b,c" {
+		t.Errorf("Expected a,b,c, got %s", got)
+	}
+}
+
+func TestExecutionPlan_GetExecutionOrderUnknownDependency(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []*Test{
+		{ID: "a", Execute: noop},
+		{ID: "b", Dependencies: []string{"a", "ghost"}, Execute: noop},
+		{ID: "c", Dependencies: []string{"ghost"}, Execute: noop},
+	}
+	// Built directly so ordering is checked on its own
+	plan := &ExecutionPlan{tests: tests}
+
+	_, err := plan.GetExecutionOrder()
+	if err == nil {
+		t.Fatal("Expected unknown dependency error, got nil")
+	}
+	if strings.Contains(err.Error(), "circular") {
+		t.Errorf("Expected an unknown dependency error rather than a cycle, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "unknown dependency IDs: ghost (required by b, c)") {
+		t.Errorf("Expected the unknown ID and its dependents in the error, got %q", err.Error())
+	}
+}
+
+func TestExecutionPlan_SelfDependency(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []*Test{
+		{ID: "a", Execute: noop},
+		{ID: "loop", Dependencies: []string{"a", "loop"}, Execute: noop},
+	}
+
+	_, err := NewExecutionPlan(tests)
+	if err == nil {
+		t.Fatal("Expected self-dependency error, got nil")
+	}
+	if !strings.Contains(err.Error(), "test loop depends on itself") {
+		t.Errorf("Expected self-dependency in error, got %q", err.Error())
+	}
+}
+
+func TestExecutionPlan_DanglingDependency(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []*Test{
+		{ID: "a", Dependencies: []string{"ghost"}, Execute: noop},
+		{ID: "b", Dependencies: []string{"b"}, Execute: noop},
+	}
+
+	_, err := NewExecutionEngine(tests, nil)
+	if err == nil {
+		t.Fatal("Expected dangling dependency error, got nil")
+	}
+	// Both problems are reported together
+	for _, want := range []string{"unknown dependency IDs: ghost (required by a)", "test b depends on itself"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestExecutionEngine_BeforeRetry(t *testing.T) {
+	var counter, beforeRetryCalls, executions int
+
+	tests := []*Test{
+		{
+			ID:         "stateful",
+			MaxRetries: 2,
+			BeforeRetry: func(ctx context.Context, attempt int) error {
+				beforeRetryCalls++
+				counter = 0
+				return nil
+			},
+			Execute: func(ctx context.Context) error {
+				executions++
+				counter++
+				if executions == 1 {
+					if beforeRetryCalls != 0 {
+						t.Error("Expected BeforeRetry not to run before the first attempt")
+					}
+					// First attempt leaves dirty state behind and fails
+					counter++
+					return errors.New("transient failure")
+				}
+				if counter != 1 {
+					return errors.New("state was not reset before retry")
+				}
+				return nil
+			},
+		},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		EnableRetry:   true,
+		Sleeper:       func(time.Duration) {},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if results[0].Status != TestStatusPassed {
+		t.Errorf("Expected test to pass on retry, got %s: %s", results[0].Status, results[0].ErrorMessage)
+	}
+	if beforeRetryCalls != 1 {
+		t.Errorf("Expected BeforeRetry to be called once, got %d", beforeRetryCalls)
+	}
+}
+
+func TestExecutionEngine_BeforeRetryFailureAborts(t *testing.T) {
+	attempts := 0
+	tests := []*Test{
+		{
+			ID:         "unrecoverable",
+			MaxRetries: 3,
+			BeforeRetry: func(ctx context.Context, attempt int) error {
+				return errors.New("cannot reset")
+			},
+			Execute: func(ctx context.Context) error {
+				attempts++
+				return errors.New("failed")
+			},
+		},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		EnableRetry:   true,
+		Sleeper:       func(time.Duration) {},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, _ := engine.Run()
+
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt after BeforeRetry failed, got %d", attempts)
+	}
+	if !strings.Contains(results[0].ErrorMessage, "cannot reset") {
+		t.Errorf("Expected BeforeRetry error to be reported, got %q", results[0].ErrorMessage)
+	}
+}
+
+func TestExecutionEngine_MaxTestsPerSecond(t *testing.T) {
+	tests := make([]*Test, 30)
+	for i := range tests {
+		tests[i] = &Test{
+			ID:      fmt.Sprintf("fast-%02d", i),
+			Execute: func(ctx context.Context) error { return nil },
+		}
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:              ExecutionModeParallel,
+		MaxWorkers:        10,
+		GlobalTimeout:     30 * time.Second,
+		TestTimeout:       time.Second,
+		MaxTestsPerSecond: 10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	start := time.Now()
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if len(results) != 30 {
+		t.Fatalf("Expected 30 results, got %d", len(results))
+	}
+	if elapsed < 2*time.Second {
+		t.Errorf("Expected run to take at least 2s at 10 tests/s, took %v", elapsed)
+	}
+
+	starts := make([]time.Time, len(results))
+	for i, r := range results {
+		starts[i] = r.StartTime
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	// No one-second window may contain more than the rate allows (plus the first token)
+	for i := range starts {
+		inWindow := 0
+		for j := i; j < len(starts) && starts[j].Sub(starts[i]) < time.Second; j++ {
+			inWindow++
+		}
+		if inWindow > 11 {
+			t.Fatalf("Expected at most 11 starts per second, got %d starting at %v", inWindow, starts[i])
+		}
+	}
+}
+
+func TestExecutionEngine_PanicRecovery(t *testing.T) {
+	var tornDown atomic.Bool
+	tests := []*Test{
+		{
+			ID:       "panics",
+			Name:     "Panicking Test",
+			Execute:  func(ctx context.Context) error { panic("index out of range") },
+			Teardown: func(ctx context.Context) error { tornDown.Store(true); return nil },
+		},
+		{
+			ID:      "healthy-1",
+			Name:    "Healthy Test 1",
+			Execute: func(ctx context.Context) error { return nil },
+		},
+		{
+			ID:      "healthy-2",
+			Name:    "Healthy Test 2",
+			Execute: func(ctx context.Context) error { return nil },
+		},
+	}
+
+	config := &RunConfig{
+		Mode:          ExecutionModeParallel,
+		MaxWorkers:    2,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+	}
+
+	engine, err := NewExecutionEngine(tests, config)
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for _, result := range results {
+		switch result.TestID {
+		case "panics":
+			if result.Status != TestStatusFailed {
+				t.Errorf("Expected panicking test to fail, got %s", result.Status)
+			}
+			if !strings.Contains(result.ErrorMessage, "index out of range") {
+				t.Errorf("Expected panic message in error, got %q", result.ErrorMessage)
+			}
+		default:
+			if result.Status != TestStatusPassed {
+				t.Errorf("Expected %s to pass, got %s", result.TestID, result.Status)
+			}
+		}
+	}
+	if !tornDown.Load() {
+		t.Error("Expected teardown to run after the panic")
+	}
+}
+
+func TestEstimateDuration_CriticalPath(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []*Test{
+		{ID: "build", Execute: noop},
+		{ID: "unit", Dependencies: []string{"build"}, Execute: noop},
+		{ID: "lint", Execute: noop},
+		{ID: "e2e", Dependencies: []string{"unit"}, Execute: noop},
+	}
+	history := map[string]time.Duration{
+		"build": 2 * time.Second,
+		"unit":  3 * time.Second,
+		"lint":  time.Second,
+		"e2e":   4 * time.Second,
+	}
+	config := &RunConfig{Mode: ExecutionModeParallel, MaxWorkers: 4, TestTimeout: time.Minute}
+
+	// build -> unit -> e2e is the critical path; the naive sum is 10s
+	if got := EstimateDuration(tests, history, config); got != 9*time.Second {
+		t.Errorf("Expected critical path estimate of 9s, got %v", got)
+	}
+
+	config.Mode = ExecutionModeSequential
+	if got := EstimateDuration(tests, history, config); got != 10*time.Second {
+		t.Errorf("Expected sequential estimate of 10s, got %v", got)
+	}
+
+	// A test without history is estimated at the historical average
+	config.Mode = ExecutionModeParallel
+	tests = append(tests, &Test{ID: "docs", Execute: noop})
+	if got := EstimateDuration(tests, history, config); got != 9*time.Second {
+		t.Errorf("Expected unknown test to stay off the critical path, got %v", got)
+	}
+}
+
+func TestTestResults_CategorizeFailures(t *testing.T) {
+	results := &TestResults{
+		Results: []*ExecutionResult{
+			{TestID: "checkout", Status: TestStatusFailed, ErrorMessage: "timeout after 5s"},
+			{TestID: "search", Status: TestStatusFailed, ErrorMessage: "timeout after 7s"},
+			{TestID: "login", Status: TestStatusFailed, ErrorMessage: "session 9f86d081884c expired"},
+			{TestID: "cart", Status: TestStatusPassed},
+		},
+	}
+
+	categories := results.CategorizeFailures()
+	if len(categories) != 2 {
+		t.Fatalf("Expected 2 failure categories, got %d: %v", len(categories), categories)
+	}
+
+	timeouts := categories["timeout after Ns"]
+	sort.Strings(timeouts)
+	if fmt.Sprint(timeouts) != "[checkout search]" {
+		t.Errorf("Expected both timeouts in one bucket, got %v", categories)
+	}
+	if ids := categories["session <id> expired"]; len(ids) != 1 || ids[0] != "login" {
+		t.Errorf("Expected login under a normalized session signature, got %v", categories)
+	}
+}
+
+func TestExecutionEngine_RecordOutcome(t *testing.T) {
+	tests := []*Test{
+		{
+			ID:   "measured",
+			Name: "Measured Test",
+			Execute: func(ctx context.Context) error {
+				RecordOutcome(ctx, &OutcomeResult{MetricName: "latency_ms", ActualValue: 120, TargetValue: 200, MetTarget: true})
+				return nil
+			},
+		},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results) != 1 || len(results[0].OutcomeResults) != 1 {
+		t.Fatalf("Expected one result carrying one outcome, got %+v", results)
+	}
+	if got := results[0].OutcomeResults[0]; got.MetricName != "latency_ms" || got.ActualValue != 120 {
+		t.Errorf("Expected recorded latency_ms outcome, got %+v", got)
+	}
+
+	// Outside an engine run RecordOutcome is a no-op
+	RecordOutcome(context.Background(), &OutcomeResult{MetricName: "ignored"})
+}
+
+// layeredTests builds a DAG of layers tests each, where every test depends on
+// every test in the previous layer. run is called from each test's Execute.
+func layeredTests(layers, width int, run func(id string, deps []string) error) []*Test {
+	var tests []*Test
+	var prev []string
+	for l := 0; l < layers; l++ {
+		var layer []string
+		for w := 0; w < width; w++ {
+			id := fmt.Sprintf("L%d-%d", l, w)
+			deps := append([]string(nil), prev...)
+			tests = append(tests, &Test{
+				ID:           id,
+				Name:         id,
+				Dependencies: deps,
+				Execute:      func(ctx context.Context) error { return run(id, deps) },
+			})
+			layer = append(layer, id)
+		}
+		prev = layer
+	}
+	return tests
+}
+
+func TestExecutionEngine_DispatchesByPriority(t *testing.T) {
+	type spec struct {
+		id       string
+		priority int
+		deps     []string
+	}
+	cases := []struct {
+		name  string
+		tests []spec
+		want  string
+	}{
+		{
+			name: "dependents wait for their dependency",
+			tests: []spec{
+				{"low", 1, nil},
+				{"base", 0, nil},
+				{"high", 10, nil},
+				{"minor-child", 2, []string{"base"}},
+				// Highest priority, but must still wait for its dependency
+				{"urgent-child", 100, []string{"base"}},
+				{"mid", 5, nil},
+			},
+			want: "high,mid,low,base,urgent-child,minor-child",
+		},
+		{
+			// A newly ready dependent must not jump ahead of queued work
+			// with a higher priority
+			name: "ready dependent queues behind higher priority",
+			tests: []spec{
+				{"a", 10, nil},
+				{"b", 5, nil},
+				{"d", 0, []string{"a"}},
+			},
+			want: "a,b,d",
+		},
+	}
+
+	for _, tc := range cases {
+		for _, scheduler := range []Scheduler{SchedulerDispatcher, SchedulerWorkStealing} {
+			t.Run(tc.name+"/"+string(scheduler), func(t *testing.T) {
+				var mu sync.Mutex
+				var order []string
+				record := func(id string) func(ctx context.Context) error {
+					return func(ctx context.Context) error {
+						mu.Lock()
+						defer mu.Unlock()
+						order = append(order, id)
+						return nil
+					}
+				}
+
+				var tests []*Test
+				for _, s := range tc.tests {
+					tests = append(tests, &Test{ID: s.id, Priority: s.priority, Dependencies: s.deps, Execute: record(s.id)})
+				}
+
+				engine, err := NewExecutionEngine(tests, &RunConfig{
+					Mode:          ExecutionModeParallel,
+					Scheduler:     scheduler,
+					MaxWorkers:    1,
+					GlobalTimeout: 10 * time.Second,
+					TestTimeout:   time.Second,
+				})
+				if err != nil {
+					t.Fatalf("Failed to create engine: %v", err)
+				}
+				if _, err := engine.Run(); err != nil {
+					t.Fatalf("Run failed: %v", err)
+				}
+
+				if got := strings.Join(order, ","); got != tc.want {
+					t.Errorf("Expected %s, got %s", tc.want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestExecutionEngine_StopOnFirstFailure(t *testing.T) {
+	for _, scheduler := range []Scheduler{SchedulerDispatcher, SchedulerWorkStealing} {
+		t.Run(string(scheduler), func(t *testing.T) {
+			slow := func(ctx context.Context) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(2 * time.Second):
+					return nil
+				}
+			}
+			tests := []*Test{
+				{ID: "boom", Priority: 10, Execute: func(ctx context.Context) error { return fmt.Errorf("boom") }},
+			}
+			for i := 0; i < 6; i++ {
+				tests = append(tests, &Test{ID: fmt.Sprintf("slow-%d", i), Execute: slow})
+			}
+
+			engine, err := NewExecutionEngine(tests, &RunConfig{
+				Mode:               ExecutionModeParallel,
+				MaxWorkers:         2,
+				GlobalTimeout:      10 * time.Second,
+				TestTimeout:        5 * time.Second,
+				Scheduler:          scheduler,
+				StopOnFirstFailure: true,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create engine: %v", err)
+			}
+
+			start := time.Now()
+			results, err := engine.Run()
+			if err == nil || !strings.Contains(err.Error(), "boom") {
+				t.Errorf("Expected the run to report the failure of boom, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected in-flight tests to be abandoned, run took %v", elapsed)
+			}
+			if len(results) != len(tests) {
+				t.Errorf("Expected a result for every test, got %d", len(results))
+			}
+
+			skipped := 0
+			for _, result := range results {
+				if result.Status == TestStatusPassed {
+					t.Errorf("Expected %s not to pass after the run was stopped", result.TestID)
+				}
+				if result.Status == TestStatusSkipped {
+					skipped++
+				}
+			}
+			if skipped < 4 {
+				t.Errorf("Expected at least 4 unstarted tests to be skipped, got %d", skipped)
+			}
+		})
+	}
+}
+
+func TestExecutionEngine_OnResult(t *testing.T) {
+	var calls, inFlight int32
+	var statuses []TestStatus
+
+	tests := layeredTests(3, 4, func(id string, deps []string) error {
+		if id == "L2-0" {
+			return fmt.Errorf("fail")
+		}
+		return nil
+	})
+
+	var engine *ExecutionEngine
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeParallel,
+		MaxWorkers:    4,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		OnResult: func(result *ExecutionResult) {
+			// Unsynchronized on purpose: calls must be serialized
+			inFlight++
+			if inFlight != 1 {
+				t.Errorf("Expected serialized callbacks, %d in flight", inFlight)
+			}
+			calls++
+			statuses = append(statuses, result.Status)
+			engine.GetMetrics() // must not deadlock
+			time.Sleep(time.Millisecond)
+			inFlight--
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if int(calls) != len(results) || len(results) != len(tests) {
+		t.Errorf("Expected %d callbacks, got %d for %d results", len(tests), calls, len(results))
+	}
+	for _, status := range statuses {
+		switch status {
+		case TestStatusPassed, TestStatusFailed, TestStatusSkipped, TestStatusQuarantined:
+		default:
+			t.Errorf("Expected only terminal statuses, got %q", status)
+		}
+	}
+}
+
+func TestExecutionEngine_RetryableError(t *testing.T) {
+	errAssertion := errors.New("assertion failed")
+	errTransient := errors.New("connection reset")
+
+	attempts := make(map[string]int)
+	var mu sync.Mutex
+	failWith := func(id string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[id]++
+			return err
+		}
+	}
+
+	tests := []*Test{
+		{ID: "assertion", MaxRetries: 3, Execute: failWith("assertion", errAssertion)},
+		{ID: "transient", MaxRetries: 1, Execute: failWith("transient", errTransient)},
+	}
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeParallel,
+		MaxWorkers:    2,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		EnableRetry:   true,
+		Seed:          1,
+		Sleeper:       func(time.Duration) {},
+		RetryableError: func(err error) bool {
+			return !errors.Is(err, errAssertion)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	byID := make(map[string]*ExecutionResult)
+	for _, result := range results {
+		byID[result.TestID] = result
+	}
+
+	if r := byID["assertion"]; r == nil || r.RetryCount != 0 || attempts["assertion"] != 1 {
+		t.Errorf("Expected a non-retryable error to stop after one attempt, got %d attempts", attempts["assertion"])
+	}
+	if r := byID["transient"]; r == nil || r.RetryCount != 1 || attempts["transient"] != 2 {
+		t.Errorf("Expected a retryable error to be retried once, got %d attempts", attempts["transient"])
+	}
+}
+
+func TestExecutionEngine_SleeperRecordsBackoff(t *testing.T) {
+	var sleeps []time.Duration
+	tests := []*Test{{ID: "flaky", MaxRetries: 3, Execute: func(ctx context.Context) error { return errors.New("flaky") }}}
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeSequential,
+		MaxWorkers:    1,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		EnableRetry:   true,
+		Seed:          7,
+		Sleeper:       func(d time.Duration) { sleeps = append(sleeps, d) },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := engine.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= defaultBackoffBase {
+		t.Errorf("Expected no wall-clock backoff, run took %v", elapsed)
+	}
+
+	// Default exponential backoff: 200ms, 400ms, 800ms, each +/- one base delay
+	if len(sleeps) != 3 {
+		t.Fatalf("Expected 3 backoff sleeps, got %v", sleeps)
+	}
+	for i, d := range sleeps {
+		nominal := time.Duration(1<<(i+1)) * defaultBackoffBase
+		if d < nominal-defaultBackoffBase || d > nominal+defaultBackoffBase {
+			t.Errorf("Retry %d: expected %v +/- %v, got %v", i+1, nominal, defaultBackoffBase, d)
+		}
+	}
+}
+
+func TestExecutionEngine_RetryStopsWhenCanceled(t *testing.T) {
+	errFlaky := errors.New("flaky")
+
+	t.Run("canceled during sleeper", func(t *testing.T) {
+		var runs int
+		tests := []*Test{{ID: "flaky", MaxRetries: 3, Execute: func(ctx context.Context) error {
+			runs++
+			return errFlaky
+		}}}
+		var engine *ExecutionEngine
+		engine, err := NewExecutionEngine(tests, &RunConfig{
+			Mode:          ExecutionModeSequential,
+			MaxWorkers:    1,
+			GlobalTimeout: 10 * time.Second,
+			TestTimeout:   time.Second,
+			EnableRetry:   true,
+			Sleeper:       func(time.Duration) { engine.cancel() },
+		})
+		if err != nil {
+			t.Fatalf("Failed to create engine: %v", err)
+		}
+
+		results, _ := engine.Run()
+		if runs != 1 {
+			t.Errorf("Expected no retries after cancellation, got %d runs", runs)
+		}
+		if len(results) != 1 || !errors.Is(results[0].Error, context.Canceled) {
+			t.Fatalf("Expected a context.Canceled failure, got %+v", results)
+		}
+		if !errors.Is(results[0].Error, errFlaky) {
+			t.Errorf("Expected the test's own error to survive cancellation, got %v", results[0].Error)
+		}
+	})
+
+	t.Run("canceled during backoff", func(t *testing.T) {
+		tests := []*Test{{ID: "flaky", MaxRetries: 3, Execute: func(ctx context.Context) error { return errFlaky }}}
+		engine, err := NewExecutionEngine(tests, &RunConfig{
+			Mode:          ExecutionModeSequential,
+			MaxWorkers:    1,
+			GlobalTimeout: 50 * time.Millisecond,
+			TestTimeout:   time.Second,
+			EnableRetry:   true,
+			Backoff:       BackoffConstant,
+			BackoffBase:   time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create engine: %v", err)
+		}
+
+		start := time.Now()
+		results, _ := engine.Run()
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected the backoff wait to end with the run, took %v", elapsed)
+		}
+		if len(results) != 1 || !errors.Is(results[0].Error, errFlaky) {
+			t.Fatalf("Expected the test's own error to survive cancellation, got %+v", results)
+		}
+		if !strings.Contains(results[0].Error.Error(), "retry canceled") {
+			t.Errorf("Expected the error to note the canceled retry, got %v", results[0].Error)
+		}
+	})
+}
+
+func TestExecutionEngine_BackoffStrategies(t *testing.T) {
+	sleepsFor := func(t *testing.T, config RunConfig) []time.Duration {
+		t.Helper()
+		var sleeps []time.Duration
+		config.Mode = ExecutionModeSequential
+		config.MaxWorkers = 1
+		config.GlobalTimeout = 10 * time.Second
+		config.TestTimeout = time.Second
+		config.EnableRetry = true
+		config.Seed = 1
+		config.Sleeper = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+		tests := []*Test{{ID: "flaky", MaxRetries: 4, Execute: func(ctx context.Context) error { return errors.New("flaky") }}}
+		engine, err := NewExecutionEngine(tests, &config)
+		if err != nil {
+			t.Fatalf("Failed to create engine: %v", err)
+		}
+		results, err := engine.Run()
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if len(results) != 1 || results[0].RetryCount != 4 {
+			t.Fatalf("Expected 4 retries, got %+v", results)
+		}
+		return sleeps
+	}
+
+	t.Run("constant", func(t *testing.T) {
+		sleeps := sleepsFor(t, RunConfig{Backoff: BackoffConstant, BackoffBase: 50 * time.Millisecond})
+		if len(sleeps) != 4 {
+			t.Fatalf("Expected 4 sleeps, got %v", sleeps)
+		}
+		for _, d := range sleeps {
+			if d != 50*time.Millisecond {
+				t.Errorf("Expected equal 50ms sleeps, got %v", sleeps)
+				break
+			}
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		if sleeps := sleepsFor(t, RunConfig{Backoff: BackoffNone}); len(sleeps) != 0 {
+			t.Errorf("Expected immediate retries, got sleeps %v", sleeps)
+		}
+	})
+
+	t.Run("linear capped", func(t *testing.T) {
+		sleeps := sleepsFor(t, RunConfig{Backoff: BackoffLinear, BackoffBase: 10 * time.Millisecond, BackoffMax: 25 * time.Millisecond})
+		want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond, 25 * time.Millisecond}
+		if fmt.Sprint(sleeps) != fmt.Sprint(want) {
+			t.Errorf("Expected sleeps %v, got %v", want, sleeps)
+		}
+	})
+
+	t.Run("exponential reproducible", func(t *testing.T) {
+		config := RunConfig{BackoffBase: time.Millisecond}
+		first, second := sleepsFor(t, config), sleepsFor(t, config)
+		if fmt.Sprint(first) != fmt.Sprint(second) {
+			t.Errorf("Expected the same seed to give the same jitter, got %v and %v", first, second)
+		}
+		for i, d := range first {
+			nominal := time.Duration(1<<(i+1)) * time.Millisecond
+			if d < nominal-time.Millisecond || d > nominal+time.Millisecond {
+				t.Errorf("Retry %d: expected %v +/- 1ms, got %v", i+1, nominal, d)
+			}
+		}
+	})
+
+	if _, err := NewExecutionEngine([]*Test{{ID: "a", Execute: func(ctx context.Context) error { return nil }}}, &RunConfig{Backoff: "fibonacci"}); err == nil {
+		t.Error("Expected error for unknown backoff strategy")
+	}
+}
+
+func TestExecutionEngine_SkipReasonNamesFailedDependency(t *testing.T) {
+	for _, scheduler := range []Scheduler{SchedulerDispatcher, SchedulerWorkStealing} {
+		t.Run(string(scheduler), func(t *testing.T) {
+			pass := func(ctx context.Context) error { return nil }
+			tests := []*Test{
+				{ID: "root", Execute: func(ctx context.Context) error { return errors.New("root broke") }},
+				{ID: "mid", Dependencies: []string{"root"}, Execute: pass},
+				{ID: "leaf", Dependencies: []string{"mid"}, Execute: pass},
+				{ID: "other", Execute: pass},
+			}
+
+			engine, err := NewExecutionEngine(tests, &RunConfig{
+				Mode:          ExecutionModeParallel,
+				MaxWorkers:    2,
+				GlobalTimeout: 10 * time.Second,
+				TestTimeout:   time.Second,
+				Scheduler:     scheduler,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create engine: %v", err)
+			}
+
+			start := time.Now()
+			results, err := engine.Run()
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("Expected blocked tests to be skipped promptly, run took %v", elapsed)
+			}
+
+			byID := make(map[string]*ExecutionResult)
+			for _, result := range results {
+				byID[result.TestID] = result
+			}
+			for _, id := range []string{"mid", "leaf"} {
+				r := byID[id]
+				if r == nil || r.Status != TestStatusSkipped || r.SkipReason != "dependency 'root' failed" {
+					t.Errorf("Expected %s skipped because root failed, got %+v", id, r)
+				}
+			}
+			if r := byID["other"]; r == nil || r.Status != TestStatusPassed {
+				t.Errorf("Expected the independent test to pass, got %+v", r)
+			}
+		})
+	}
+}
+
+func TestDependencyFailedReason(t *testing.T) {
+	if got := dependencyFailedReason([]string{"a", "b"}); got != "dependencies 'a', 'b' failed" {
+		t.Errorf("Unexpected reason %q", got)
+	}
+}
+
+func TestExecutionEngine_RejectsUnknownScheduler(t *testing.T) {
+	tests := []*Test{{ID: "a", Execute: func(ctx context.Context) error { return nil }}}
+	for _, scheduler := range []Scheduler{"", SchedulerDispatcher, SchedulerWorkStealing} {
+		if _, err := NewExecutionEngine(tests, &RunConfig{Scheduler: scheduler}); err != nil {
+			t.Errorf("Scheduler %q: unexpected error %v", scheduler, err)
+		}
+	}
+
+	if _, err := NewExecutionEngine(tests, &RunConfig{Scheduler: "work_stealing"}); err == nil {
+		t.Error("Expected error for unknown scheduler")
+	}
+}
+
+func TestExecutionEngine_StartBudget(t *testing.T) {
+	configs := map[string]RunConfig{
+		"sequential":    {Mode: ExecutionModeSequential},
+		"fail-fast":     {Mode: ExecutionModeFailFast},
+		"dispatcher":    {Mode: ExecutionModeParallel, Scheduler: SchedulerDispatcher},
+		"work-stealing": {Mode: ExecutionModeParallel, Scheduler: SchedulerWorkStealing},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			slow := func(ctx context.Context) error {
+				time.Sleep(150 * time.Millisecond)
+				return nil
+			}
+			tests := []*Test{
+				{ID: "first", Priority: 10, Execute: slow},
+				{ID: "second", Priority: 5, Execute: slow},
+				{ID: "third", Dependencies: []string{"second"}, Execute: slow},
+			}
+			config.MaxWorkers = 1
+			config.GlobalTimeout = 10 * time.Second
+			config.TestTimeout = time.Second
+			config.StartBudget = 50 * time.Millisecond
+
+			engine, err := NewExecutionEngine(tests, &config)
+			if err != nil {
+				t.Fatalf("Failed to create engine: %v", err)
+			}
+			results, err := engine.Run()
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			statuses := make(map[string]*ExecutionResult)
+			for _, result := range results {
+				statuses[result.TestID] = result
+			}
+			if statuses["first"] == nil || statuses["first"].Status != TestStatusPassed {
+				t.Errorf("Expected first to run to completion, got %+v", statuses["first"])
+			}
+			for _, id := range []string{"second", "third"} {
+				if result := statuses[id]; result == nil || result.Status != TestStatusSkipped || result.SkipReason != SkipReasonTimeBudget {
+					t.Errorf("Expected %s skipped for the time budget, got %+v", id, result)
+				}
+			}
+			if metrics := engine.GetMetrics(); metrics.BudgetSkipped != 2 || metrics.Skipped != 2 {
+				t.Errorf("Expected 2 budget skips, got %+v", metrics)
+			}
+		})
+	}
+}
+
+func TestExecutionEngine_WorkStealingRunsOnceInOrder(t *testing.T) {
+	var mu sync.Mutex
+	runs := make(map[string]int)
+	var violations []string
+
+	tests := layeredTests(6, 5, func(id string, deps []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, dep := range deps {
+			if runs[dep] == 0 {
+				violations = append(violations, fmt.Sprintf("%s ran before %s", id, dep))
+			}
+		}
+		runs[id]++
+		return nil
+	})
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeParallel,
+		MaxWorkers:    4,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		Scheduler:     SchedulerWorkStealing,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results) != len(tests) {
+		t.Errorf("Expected %d results, got %d", len(tests), len(results))
+	}
+	for _, test := range tests {
+		if runs[test.ID] != 1 {
+			t.Errorf("Expected %s to run exactly once, ran %d times", test.ID, runs[test.ID])
+		}
+	}
+	for _, v := range violations {
+		t.Error(v)
+	}
+}
+
+func TestExecutionEngine_WorkStealingSkipsDependentsOfFailure(t *testing.T) {
+	tests := []*Test{
+		{ID: "a", Name: "a", Execute: func(ctx context.Context) error { return fmt.Errorf("boom") }},
+		{ID: "b", Name: "b", Dependencies: []string{"a"}, Execute: func(ctx context.Context) error { return nil }},
+		{ID: "c", Name: "c", Dependencies: []string{"b"}, Execute: func(ctx context.Context) error { return nil }},
+		{ID: "e", Name: "e", Execute: func(ctx context.Context) error { return nil }},
+	}
+
+	engine, err := NewExecutionEngine(tests, &RunConfig{
+		Mode:          ExecutionModeComprehensive,
+		MaxWorkers:    2,
+		GlobalTimeout: 10 * time.Second,
+		TestTimeout:   time.Second,
+		Scheduler:     SchedulerWorkStealing,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	results, err := engine.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	status := make(map[string]TestStatus)
+	for _, r := range results {
+		status[r.TestID] = r.Status
+	}
+	want := map[string]TestStatus{
+		"a": TestStatusFailed,
+		"b": TestStatusSkipped,
+		"c": TestStatusSkipped,
+		"e": TestStatusPassed,
+	}
+	if len(results) != len(want) {
+		t.Errorf("Expected %d results, got %d", len(want), len(results))
+	}
+	for id, s := range want {
+		if status[id] != s {
+			t.Errorf("Expected %s to be %s, got %s", id, s, status[id])
+		}
+	}
+}
+
+func benchmarkScheduler(b *testing.B, scheduler Scheduler) {
+	for i := 0; i < b.N; i++ {
+		tests := layeredTests(5, 8, func(id string, deps []string) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		engine, err := NewExecutionEngine(tests, &RunConfig{
+			Mode:          ExecutionModeParallel,
+			MaxWorkers:    8,
+			GlobalTimeout: time.Minute,
+			TestTimeout:   time.Second,
+			Scheduler:     scheduler,
+		})
+		if err != nil {
+			b.Fatalf("Failed to create engine: %v", err)
+		}
+		if _, err := engine.Run(); err != nil {
+			b.Fatalf("Run failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkExecutionEngine_Dispatcher(b *testing.B) {
+	benchmarkScheduler(b, SchedulerDispatcher)
+}
+
+func BenchmarkExecutionEngine_WorkStealing(b *testing.B) {
+	benchmarkScheduler(b, SchedulerWorkStealing)
+}
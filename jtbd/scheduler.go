@@ -0,0 +1,282 @@
+package jtbd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// workDeque is a worker's local queue of ready tests, kept ordered by
+// Priority. The owner pops the highest-priority test from the bottom, so a
+// dependency chain tends to stay on one worker; thieves steal from the top,
+// taking the least urgent work.
+type workDeque struct {
+	mu    sync.Mutex
+	tests []*Test
+}
+
+// pushBottom inserts a test behind every queued test of the same or higher
+// Priority, so equal priorities pop in the order they were pushed
+func (d *workDeque) pushBottom(test *Test) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := sort.Search(len(d.tests), func(i int) bool { return d.tests[i].Priority >= test.Priority })
+	d.tests = append(d.tests, nil)
+	copy(d.tests[i+1:], d.tests[i:])
+	d.tests[i] = test
+}
+
+// popBottom removes the highest-priority test, or returns nil
+func (d *workDeque) popBottom() *Test {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tests) == 0 {
+		return nil
+	}
+	test := d.tests[len(d.tests)-1]
+	d.tests = d.tests[:len(d.tests)-1]
+	return test
+}
+
+// stealTop removes the lowest-priority test, or returns nil
+func (d *workDeque) stealTop() *Test {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tests) == 0 {
+		return nil
+	}
+	test := d.tests[0]
+	d.tests = d.tests[1:]
+	return test
+}
+
+// stealingScheduler resolves dependencies for a work-stealing run. Instead
+// of polling the plan, finishing a test decrements its dependents' pending
+// counts and queues those that reach zero.
+type stealingScheduler struct {
+	ee     *ExecutionEngine
+	deques []*workDeque
+
+	// queued counts tests sitting in deques. It is incremented under mu so
+	// idle workers waiting on cond never miss new work.
+	queued atomic.Int32
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	remaining  int // tests without a result
+	pending    map[string]int
+	dependents map[string][]*Test
+	queuedSet  map[string]bool // tests queued or skipped, never to be queued again
+	started    map[string]bool // tests a worker has taken or that were skipped
+}
+
+// newStealingScheduler builds the dependency bookkeeping for ee's tests
+func newStealingScheduler(ee *ExecutionEngine) *stealingScheduler {
+	s := &stealingScheduler{
+		ee:         ee,
+		deques:     make([]*workDeque, ee.config.MaxWorkers),
+		remaining:  len(ee.tests),
+		pending:    make(map[string]int, len(ee.tests)),
+		dependents: make(map[string][]*Test),
+		queuedSet:  make(map[string]bool, len(ee.tests)),
+		started:    make(map[string]bool, len(ee.tests)),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	for i := range s.deques {
+		s.deques[i] = &workDeque{}
+	}
+	for _, test := range ee.tests {
+		s.pending[test.ID] = len(test.Dependencies)
+		for _, dep := range test.Dependencies {
+			s.dependents[dep] = append(s.dependents[dep], test)
+		}
+	}
+	return s
+}
+
+// runWorkStealing executes tests with per-worker deques and stealing.
+func (ee *ExecutionEngine) runWorkStealing() {
+	s := newStealingScheduler(ee)
+	stop := context.AfterFunc(ee.dispatchCtx, s.wakeAll)
+	defer stop()
+
+	s.seed()
+	for i := range s.deques {
+		ee.wg.Add(1)
+		go s.worker(i)
+	}
+	ee.wg.Wait()
+
+	s.skipUnstarted(ee.unstartedReason())
+}
+
+// seed spreads tests without dependencies across the deques, dealing the
+// highest Priority out first so urgent work starts on every worker.
+// NewExecutionPlan has already rejected unknown dependencies, so every
+// other test becomes ready or is skipped later.
+func (s *stealingScheduler) seed() {
+	var ready []*Test
+	s.mu.Lock()
+	for _, test := range s.ee.tests {
+		if len(test.Dependencies) == 0 {
+			s.queuedSet[test.ID] = true
+			ready = append(ready, test)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.SliceStable(ready, func(i, j int) bool { return ready[i].Priority > ready[j].Priority })
+	for i, test := range ready {
+		s.push(i%len(s.deques), test)
+	}
+}
+
+// worker runs tests from its own deque, stealing when it is empty and
+// sleeping only while no work is queued anywhere
+func (s *stealingScheduler) worker(id int) {
+	defer s.ee.wg.Done()
+
+	for {
+		test := s.take(id)
+		if test == nil {
+			if !s.wait() {
+				return
+			}
+			continue
+		}
+
+		if err := s.ee.limiter.wait(s.ee.dispatchCtx); err != nil || s.ee.dispatchCtx.Err() != nil {
+			// Leave this and everything else unstarted for skipUnstarted
+			return
+		}
+
+		s.mu.Lock()
+		s.started[test.ID] = true
+		s.mu.Unlock()
+
+		status := s.ee.runAndRecord(test)
+		s.finish(id, test, status == TestStatusPassed)
+	}
+}
+
+// take pops from the worker's own deque, then tries to steal from the others
+func (s *stealingScheduler) take(id int) *Test {
+	if test := s.deques[id].popBottom(); test != nil {
+		s.queued.Add(-1)
+		return test
+	}
+	for i := 1; i < len(s.deques); i++ {
+		if test := s.deques[(id+i)%len(s.deques)].stealTop(); test != nil {
+			s.queued.Add(-1)
+			return test
+		}
+	}
+	return nil
+}
+
+// wait blocks until work is queued, every test has a result or the run
+// stops dispatching. It reports whether the worker should keep going.
+func (s *stealingScheduler) wait() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.queued.Load() <= 0 && s.remaining > 0 && s.ee.dispatchCtx.Err() == nil {
+		s.cond.Wait()
+	}
+	return s.remaining > 0 && s.ee.dispatchCtx.Err() == nil
+}
+
+// push queues a ready test on a worker's deque and wakes an idle worker
+func (s *stealingScheduler) push(id int, test *Test) {
+	s.deques[id].pushBottom(test)
+	s.mu.Lock()
+	s.queued.Add(1)
+	s.cond.Signal()
+	s.mu.Unlock()
+}
+
+// finish records that a test has a result. A pass queues dependents whose
+// last dependency this was onto the finishing worker's deque; anything else
+// skips its dependents transitively, naming this test as the cause.
+func (s *stealingScheduler) finish(id int, test *Test, passed bool) {
+	var ready, skipped []*Test
+
+	s.mu.Lock()
+	s.remaining--
+	if passed {
+		for _, dependent := range s.dependents[test.ID] {
+			if s.queuedSet[dependent.ID] {
+				continue
+			}
+			s.pending[dependent.ID]--
+			if s.pending[dependent.ID] == 0 {
+				s.queuedSet[dependent.ID] = true
+				ready = append(ready, dependent)
+			}
+		}
+	} else {
+		skipped = s.settleDependentsLocked(test.ID)
+	}
+	if s.remaining == 0 {
+		s.cond.Broadcast()
+	}
+	s.mu.Unlock()
+
+	reason := dependencyFailedReason([]string{test.ID})
+	for _, dependent := range skipped {
+		s.ee.skipTest(dependent, reason)
+	}
+	for _, dependent := range ready {
+		s.push(id, dependent)
+	}
+}
+
+// settleLocked marks test and its transitive dependents as never running,
+// returning them so the caller can record skips outside the lock
+func (s *stealingScheduler) settleLocked(test *Test) []*Test {
+	s.queuedSet[test.ID] = true
+	s.started[test.ID] = true
+	s.remaining--
+	return append([]*Test{test}, s.settleDependentsLocked(test.ID)...)
+}
+
+// settleDependentsLocked settles every not-yet-queued transitive dependent
+// of testID
+func (s *stealingScheduler) settleDependentsLocked(testID string) []*Test {
+	var settled []*Test
+	for _, dependent := range s.dependents[testID] {
+		if s.queuedSet[dependent.ID] {
+			continue
+		}
+		settled = append(settled, s.settleLocked(dependent)...)
+	}
+	if s.remaining == 0 {
+		s.cond.Broadcast()
+	}
+	return settled
+}
+
+// skipUnstarted records a skip for every test no worker took, such as those
+// still queued or pending when the run was canceled
+func (s *stealingScheduler) skipUnstarted(reason string) {
+	var unstarted []*Test
+	s.mu.Lock()
+	for _, test := range s.ee.tests {
+		if !s.started[test.ID] {
+			s.started[test.ID] = true
+			unstarted = append(unstarted, test)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, test := range unstarted {
+		s.ee.skipTest(test, reason)
+	}
+}
+
+// wakeAll wakes every idle worker, e.g. when the run is canceled
+func (s *stealingScheduler) wakeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cond.Broadcast()
+}
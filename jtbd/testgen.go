@@ -1,6 +1,7 @@
 package jtbd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -95,13 +96,24 @@ func (tc *TestCase) ToJob() *Job {
 
 	// Add outcome
 	if tc.OutcomeSpec.Description != "" {
+		// Measurements are keyed by outcome type; untyped failure cases
+		// still need a metric to form a valid job
+		metric := string(tc.OutcomeSpec.Type)
+		if metric == "" {
+			metric = "completion"
+		}
 		outcome := &Outcome{
 			Type:        tc.OutcomeSpec.Type,
+			Metric:      metric,
 			Description: tc.OutcomeSpec.Description,
 			Target:      tc.OutcomeSpec.Target,
 			Unit:        tc.OutcomeSpec.Unit,
 			Metadata:    tc.OutcomeSpec.Metrics,
 		}
+		// Templates carry no direction, and ScoreOutcome maximizes unless
+		// told otherwise, so declare the type's natural one (speed and cost
+		// targets are upper bounds)
+		outcome.Direction = outcomeDirection(outcome)
 		job.Outcomes = append(job.Outcomes, outcome)
 	}
 
@@ -113,10 +125,114 @@ func (tc *TestCase) ToJob() *Job {
 	return job
 }
 
+// ToTest converts a TestCase into a runnable Test for the ExecutionEngine.
+// Execute registers the job and validates its outcome against measured,
+// keyed by outcome type (e.g. "speed"). A failure case (an outcome spec with
+// Success false) always returns an error. Dependencies are the job names in
+// StepSequence; use TestsFromCases to resolve them to test case IDs.
+func (tc *TestCase) ToTest(measured map[string]float64) *Test {
+	return &Test{
+		ID:           tc.ID,
+		Name:         tc.JobSpec.Name,
+		Description:  tc.JobSpec.Description,
+		Dependencies: append([]string(nil), tc.StepSequence...),
+		Execute: func(ctx context.Context) error {
+			return tc.execute(ctx, measured)
+		},
+	}
+}
+
+// execute registers the test case's job and checks its outcome
+func (tc *TestCase) execute(ctx context.Context, measured map[string]float64) error {
+	job := tc.ToJob()
+	if err := NewJobRegistry().RegisterJob(job); err != nil {
+		return fmt.Errorf("register job %s: %w", tc.ID, err)
+	}
+
+	spec := tc.OutcomeSpec
+	if spec.Description == "" {
+		return nil
+	}
+	if !spec.Success {
+		return fmt.Errorf("test case %s expects failure: %s", tc.ID, spec.Description)
+	}
+	if spec.Type == "" || spec.Target == 0 {
+		return nil
+	}
+
+	actual, ok := measured[string(spec.Type)]
+	if !ok {
+		return fmt.Errorf("test case %s: no measurement for %s outcome", tc.ID, spec.Type)
+	}
+	result := ScoreOutcome(job.Outcomes[0], actual)
+	result.MetricName = string(spec.Type)
+	RecordOutcome(ctx, result)
+	if !result.MetTarget {
+		return fmt.Errorf("test case %s: %s outcome %.2f missed target %.2f %s",
+			tc.ID, spec.Type, actual, spec.Target, spec.Unit)
+	}
+	return nil
+}
+
+// TestsFromCases converts test cases into Tests, resolving each StepSequence
+// job name to the IDs of the cases for that job. Steps with no matching case
+// are dropped so the engine never waits on a test that does not exist.
+func TestsFromCases(cases []TestCase, measured map[string]float64) []*Test {
+	idsByName := make(map[string][]string)
+	for _, tc := range cases {
+		idsByName[tc.JobSpec.Name] = append(idsByName[tc.JobSpec.Name], tc.ID)
+	}
+
+	tests := make([]*Test, 0, len(cases))
+	for i := range cases {
+		test := cases[i].ToTest(measured)
+		var deps []string
+		for _, step := range test.Dependencies {
+			for _, id := range idsByName[step] {
+				if id != test.ID {
+					deps = append(deps, id)
+				}
+			}
+		}
+		test.Dependencies = deps
+		tests = append(tests, test)
+	}
+	return tests
+}
+
+// TestCaseError lists the problems found in one test case
+type TestCaseError struct {
+	CaseID string
+	Errors []error
+}
+
+// Error implements error
+func (e TestCaseError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("test case %s: %s", e.CaseID, strings.Join(msgs, "; "))
+}
+
+// ValidateTestCases converts each case to a job and checks it with
+// ValidateJobCompleteness without executing anything, returning one
+// TestCaseError per malformed case in input order.
+func ValidateTestCases(cases []TestCase) []TestCaseError {
+	var problems []TestCaseError
+	for i := range cases {
+		if errs := ValidateJobCompleteness(cases[i].ToJob()); len(errs) > 0 {
+			problems = append(problems, TestCaseError{CaseID: cases[i].ID, Errors: errs})
+		}
+	}
+	return problems
+}
+
 // TestCaseGenerator generates comprehensive JTBD test cases
 type TestCaseGenerator struct {
 	industryPatterns map[string]*IndustryPattern
 	testCaseCounter  int
+	idGenerator      func() string
 }
 
 // IndustryPattern defines patterns for specific industries
@@ -353,6 +469,41 @@ func (g *TestCaseGenerator) GenerateTestCases(industry string, options TestGener
 	return testCases
 }
 
+// EstimateCaseCount returns how many cases GenerateTestCases would produce
+// for industry and options, without generating them. Use it to check that a
+// combinatorial level won't explode before committing to it.
+func (g *TestCaseGenerator) EstimateCaseCount(industry string, options TestGenerationOptions) int {
+	pattern, exists := g.industryPatterns[strings.ToLower(industry)]
+	if !exists {
+		return 0
+	}
+
+	jobs := len(pattern.Jobs)
+	count := 0
+	if options.IncludeHappyPath {
+		count += jobs
+	}
+	if options.IncludeEdgeCases {
+		count += jobs
+	}
+	if options.IncludeFailures {
+		count += jobs
+	}
+	// Multi-step and competing cases pair the first two jobs
+	if options.IncludeMultiStep && jobs >= 2 {
+		count++
+	}
+	if options.IncludeCompeting && jobs >= 2 {
+		count++
+	}
+
+	// explodeCombinations keeps each base case and adds 2*level variants
+	if options.CombinatorialLevel > 0 {
+		count *= 1 + 2*options.CombinatorialLevel
+	}
+	return count
+}
+
 // generateHappyPathCases generates standard success scenarios
 func (g *TestCaseGenerator) generateHappyPathCases(pattern *IndustryPattern) []TestCase {
 	var cases []TestCase
@@ -555,11 +706,20 @@ func (g *TestCaseGenerator) explodeCombinations(baseCases []TestCase, level int)
 }
 
 func (g *TestCaseGenerator) nextID() string {
+	if g.idGenerator != nil {
+		return g.idGenerator()
+	}
 	g.testCaseCounter++
 	timestamp := time.Now().Format("20060102")
 	return fmt.Sprintf("TC-%s-%04d", timestamp, g.testCaseCounter)
 }
 
+// WithIDGenerator replaces the default TC-<date>-<counter> test case IDs with
+// IDs from fn. Passing nil restores the default.
+func (g *TestCaseGenerator) WithIDGenerator(fn func() string) {
+	g.idGenerator = fn
+}
+
 // GetAllIndustries returns all supported industries
 func (g *TestCaseGenerator) GetAllIndustries() []string {
 	return []string{"retail", "ecommerce", "technology", "healthcare", "insurance"}
@@ -1,6 +1,9 @@
 package jtbd
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -75,6 +78,32 @@ func TestGenerateRetailTestCases(t *testing.T) {
 	t.Logf("Generated %d retail test cases", len(cases))
 }
 
+func TestWithIDGenerator(t *testing.T) {
+	gen := NewTestCaseGenerator()
+
+	counter := 0
+	gen.WithIDGenerator(func() string {
+		counter++
+		return fmt.Sprintf("tc-%d", counter)
+	})
+
+	cases := gen.GenerateTestCases("retail", TestGenerationOptions{
+		IncludeHappyPath: true,
+		IncludeEdgeCases: true,
+	})
+
+	if len(cases) == 0 {
+		t.Fatal("Expected test cases to be generated, got none")
+	}
+
+	for i, tc := range cases {
+		expected := fmt.Sprintf("tc-%d", i+1)
+		if tc.ID != expected {
+			t.Errorf("Case %d: expected ID %s, got %s", i, expected, tc.ID)
+		}
+	}
+}
+
 func TestGenerateAllIndustries(t *testing.T) {
 	gen := NewTestCaseGenerator()
 
@@ -144,6 +173,99 @@ func TestTestCaseToJob(t *testing.T) {
 	t.Logf("Successfully converted TestCase to Job")
 }
 
+func TestTestCaseToTest(t *testing.T) {
+	gen := NewTestCaseGenerator()
+
+	cases := gen.GenerateTestCases("retail", TestGenerationOptions{
+		IncludeHappyPath: true,
+		IncludeFailures:  true,
+	})
+
+	var happy, failure *TestCase
+	for i := range cases {
+		switch {
+		case cases[i].IsHappyPath && happy == nil:
+			happy = &cases[i]
+		case !cases[i].OutcomeSpec.Success && cases[i].OutcomeSpec.Description != "" && failure == nil:
+			failure = &cases[i]
+		}
+	}
+	if happy == nil || failure == nil {
+		t.Fatal("Expected both happy path and failure cases")
+	}
+
+	failing := failure.ToTest(nil)
+	if failing.ID != failure.ID {
+		t.Errorf("Expected test ID %s, got %s", failure.ID, failing.ID)
+	}
+	if err := failing.Execute(context.Background()); err == nil {
+		t.Error("Expected failure case to return an error")
+	}
+
+	spec := happy.OutcomeSpec
+	passing := happy.ToTest(map[string]float64{string(spec.Type): spec.Target})
+	if err := passing.Execute(context.Background()); err != nil {
+		t.Errorf("Expected happy path to pass at its target, got %v", err)
+	}
+
+	// Retail outcomes are speed targets, so finishing early must also pass
+	quicker := happy.ToTest(map[string]float64{string(spec.Type): spec.Target / 2})
+	if err := quicker.Execute(context.Background()); err != nil {
+		t.Errorf("Expected happy path to pass under its speed target, got %v", err)
+	}
+}
+
+func TestEstimateCaseCount(t *testing.T) {
+	gen := NewTestCaseGenerator()
+
+	combinations := []TestGenerationOptions{
+		{IncludeHappyPath: true},
+		{IncludeHappyPath: true, IncludeEdgeCases: true, IncludeFailures: true},
+		{IncludeMultiStep: true, IncludeCompeting: true},
+		{IncludeHappyPath: true, IncludeFailures: true, CombinatorialLevel: 1},
+		{IncludeHappyPath: true, IncludeEdgeCases: true, IncludeFailures: true,
+			IncludeMultiStep: true, IncludeCompeting: true, CombinatorialLevel: 3},
+	}
+
+	for _, industry := range append(gen.GetAllIndustries(), "unknown") {
+		for i, options := range combinations {
+			estimate := gen.EstimateCaseCount(industry, options)
+			actual := len(gen.GenerateTestCases(industry, options))
+			if estimate != actual {
+				t.Errorf("%s, options %d: estimated %d cases, generated %d", industry, i, estimate, actual)
+			}
+		}
+	}
+}
+
+func TestValidateTestCases(t *testing.T) {
+	gen := NewTestCaseGenerator()
+	cases := gen.GenerateTestCases("retail", TestGenerationOptions{
+		IncludeHappyPath: true,
+		IncludeEdgeCases: true,
+		IncludeFailures:  true,
+	})
+	if problems := ValidateTestCases(cases); len(problems) != 0 {
+		t.Fatalf("Expected generated cases to be valid, got %v", problems)
+	}
+
+	broken := TestCase{
+		ID:       "retail-broken",
+		Industry: "retail",
+		JobSpec:  TestJobSpec{Name: "Restock shelves", Functional: "   "},
+	}
+	problems := ValidateTestCases(append(cases, broken))
+	if len(problems) != 1 {
+		t.Fatalf("Expected 1 flagged case, got %d: %v", len(problems), problems)
+	}
+	if problems[0].CaseID != "retail-broken" {
+		t.Errorf("Expected retail-broken to be flagged, got %s", problems[0].CaseID)
+	}
+	if !strings.Contains(problems[0].Error(), "functional dimension is required") {
+		t.Errorf("Expected a functional dimension error, got %q", problems[0].Error())
+	}
+}
+
 func TestCombinatorialExplosion(t *testing.T) {
 	gen := NewTestCaseGenerator()
 
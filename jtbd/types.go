@@ -2,6 +2,8 @@
 package jtbd
 
 import (
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -11,3 +13,47 @@ type TestResults struct {
 	Metrics  TestMetrics        `json:"metrics"`
 	Duration time.Duration      `json:"duration"`
 }
+
+var (
+	// uuidPattern matches UUIDs so per-run identifiers don't split categories
+	uuidPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+
+	// hexIDPattern matches long hex identifiers such as hashes and addresses
+	hexIDPattern = regexp.MustCompile(`(?i)\b(0x)?[0-9a-f]{8,}\b`)
+
+	// numberPattern matches integers and decimals, e.g. "5" in "5s" or "0.25"
+	numberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+)
+
+// CategorizeFailures buckets failed test IDs by a normalized error signature.
+// The signature is the first line of the error message with UUIDs, hex IDs
+// and numbers replaced by placeholders, so "timeout after 5s" and "timeout
+// after 7s" share the bucket "timeout after Ns".
+func (tr *TestResults) CategorizeFailures() map[string][]string {
+	categories := make(map[string][]string)
+	for _, result := range tr.Results {
+		if result.Status != TestStatusFailed {
+			continue
+		}
+		signature := FailureSignature(result.ErrorMessage)
+		categories[signature] = append(categories[signature], result.TestID)
+	}
+	return categories
+}
+
+// FailureSignature normalizes an error message for grouping failures.
+func FailureSignature(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	message = uuidPattern.ReplaceAllString(message, "<id>")
+	message = hexIDPattern.ReplaceAllStringFunc(message, func(s string) string {
+		// Words made only of hex letters are not IDs; require a digit
+		if strings.IndexAny(s, "0123456789") < 0 {
+			return s
+		}
+		return "<id>"
+	})
+	message = numberPattern.ReplaceAllString(message, "N")
+	return strings.TrimSpace(message)
+}